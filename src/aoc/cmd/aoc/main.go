@@ -0,0 +1,121 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"adventofcode2025/day1/src/aoc"
+	_ "adventofcode2025/day1/src/day1"
+	_ "adventofcode2025/day1/src/day10"
+	_ "adventofcode2025/day1/src/day11"
+	_ "adventofcode2025/day1/src/day12"
+	_ "adventofcode2025/day1/src/day2"
+	_ "adventofcode2025/day1/src/day3"
+	_ "adventofcode2025/day1/src/day4"
+	_ "adventofcode2025/day1/src/day5"
+	_ "adventofcode2025/day1/src/day6"
+	_ "adventofcode2025/day1/src/day7"
+	_ "adventofcode2025/day1/src/day8"
+	_ "adventofcode2025/day1/src/day9"
+)
+
+const usage = `usage:
+  aoc run -day N [-part P] [-file path]
+  aoc report -day N [-part P] [-format F] [-file path]
+  aoc list`
+
+// main dispatches to the requested subcommand and exits non-zero on failure.
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "run":
+		err = runCmd(os.Args[2:])
+	case "report":
+		err = reportCmd(os.Args[2:])
+	case "list":
+		err = listCmd(os.Args[2:])
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n%s\n", os.Args[1], usage)
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+}
+
+// runCmd wires file/stdin input to the registered solver and prints the answer.
+func runCmd(args []string) error {
+	fs := flag.NewFlagSet("run", flag.ExitOnError)
+	day := fs.Int("day", 0, "puzzle day to solve")
+	part := fs.Int("part", 0, "puzzle part to solve (default: latest supported part)")
+	filePath := fs.String("file", "", "path to puzzle input file (default: stdin)")
+	fs.Parse(args)
+
+	reader, err := openInput(*filePath)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	answer, err := aoc.Run(*day, *part, reader)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "%s\n", answer)
+	return nil
+}
+
+// reportCmd wires file/stdin input to the registered solver and prints its
+// report in the requested format.
+func reportCmd(args []string) error {
+	fs := flag.NewFlagSet("report", flag.ExitOnError)
+	day := fs.Int("day", 0, "puzzle day to report on")
+	part := fs.Int("part", 0, "puzzle part to report on (default: latest supported part)")
+	format := fs.String("format", "", "report format (day specific, e.g. table or json; default: the day's own)")
+	filePath := fs.String("file", "", "path to puzzle input file (default: stdin)")
+	fs.Parse(args)
+
+	reader, err := openInput(*filePath)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	return aoc.Report(*day, *part, *format, reader, os.Stdout)
+}
+
+// openInput opens the puzzle input at path, or stdin when path is empty.
+func openInput(path string) (io.ReadCloser, error) {
+	if path == "" {
+		return io.NopCloser(os.Stdin), nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	return f, nil
+}
+
+// listCmd prints every registered day with the parts it supports.
+func listCmd(args []string) error {
+	fs := flag.NewFlagSet("list", flag.ExitOnError)
+	fs.Parse(args)
+
+	for _, day := range aoc.Days() {
+		s, err := aoc.Lookup(day)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stdout, "day %d: parts %v\n", day, s.Parts())
+	}
+	return nil
+}
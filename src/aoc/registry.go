@@ -0,0 +1,144 @@
+package aoc
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+)
+
+var (
+	ErrUnknownDay    = errors.New("unknown day")
+	ErrUnknownPart   = errors.New("unknown part")
+	ErrNoReport      = errors.New("no report available")
+	ErrUnknownFormat = errors.New("unknown report format")
+)
+
+// Solver answers the parts of a single day's puzzle.
+type Solver interface {
+	// Parts lists the supported puzzle parts in ascending order.
+	Parts() []int
+	// Solve reads the puzzle input and returns the printable answer for part.
+	Solve(part int, r io.Reader) (string, error)
+}
+
+// Reporter is implemented by solvers that can describe how they reached an
+// answer in more detail than the answer itself.
+type Reporter interface {
+	// Report reads the puzzle input and writes a report for part to w in the
+	// given format, or fails with ErrUnknownFormat. An empty format selects
+	// the day's default format.
+	Report(part int, r io.Reader, w io.Writer, format string) error
+}
+
+// PartFunc solves one part of a puzzle from its input.
+type PartFunc func(r io.Reader) (string, error)
+
+// Parts is a Solver backed by one PartFunc per supported part.
+type Parts map[int]PartFunc
+
+// Parts returns the supported parts in ascending order.
+func (p Parts) Parts() []int {
+	parts := make([]int, 0, len(p))
+	for part := range p {
+		parts = append(parts, part)
+	}
+	sort.Ints(parts)
+	return parts
+}
+
+// Solve dispatches to the PartFunc registered for part.
+func (p Parts) Solve(part int, r io.Reader) (string, error) {
+	fn, ok := p[part]
+	if !ok {
+		return "", fmt.Errorf("%w: %d", ErrUnknownPart, part)
+	}
+	return fn(r)
+}
+
+var (
+	mu      sync.RWMutex
+	solvers = make(map[int]Solver)
+)
+
+// Register makes a solver available for day. It panics if the day is already
+// registered or the solver is nil, since both are programming errors.
+func Register(day int, s Solver) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if s == nil {
+		panic(fmt.Sprintf("aoc: Register solver for day %d is nil", day))
+	}
+	if _, dup := solvers[day]; dup {
+		panic(fmt.Sprintf("aoc: Register called twice for day %d", day))
+	}
+	solvers[day] = s
+}
+
+// Lookup returns the solver registered for day.
+func Lookup(day int) (Solver, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	s, ok := solvers[day]
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownDay, day)
+	}
+	return s, nil
+}
+
+// Days returns the registered days in ascending order.
+func Days() []int {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	days := make([]int, 0, len(solvers))
+	for day := range solvers {
+		days = append(days, day)
+	}
+	sort.Ints(days)
+	return days
+}
+
+// Run solves part of day from r. A part of 0 selects the latest part the
+// solver supports.
+func Run(day, part int, r io.Reader) (string, error) {
+	s, part, err := resolve(day, part)
+	if err != nil {
+		return "", err
+	}
+	return s.Solve(part, r)
+}
+
+// Report writes the report of part of day for the input r to w. A part of 0
+// selects the latest part the solver supports.
+func Report(day, part int, format string, r io.Reader, w io.Writer) error {
+	s, part, err := resolve(day, part)
+	if err != nil {
+		return err
+	}
+	rep, ok := s.(Reporter)
+	if !ok {
+		return fmt.Errorf("day %d: %w", day, ErrNoReport)
+	}
+	return rep.Report(part, r, w, format)
+}
+
+// resolve looks up the solver of day and replaces a part of 0 with the latest
+// supported part.
+func resolve(day, part int) (Solver, int, error) {
+	s, err := Lookup(day)
+	if err != nil {
+		return nil, 0, err
+	}
+	if part == 0 {
+		parts := s.Parts()
+		if len(parts) == 0 {
+			return nil, 0, fmt.Errorf("day %d: %w", day, ErrUnknownPart)
+		}
+		part = parts[len(parts)-1]
+	}
+	return s, part, nil
+}
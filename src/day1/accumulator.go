@@ -3,39 +3,57 @@ package day1
 const dialSize = 100
 
 func wrapDial(value int) int {
-	r := value % dialSize
+	return wrapOn(dialSize, value)
+}
+
+// wrapOn reduces value onto a dial of the given size.
+func wrapOn(size, value int) int {
+	r := value % size
 	if r < 0 {
-		r += dialSize
+		r += size
 	}
 	return r
 }
 
 // Apply adjusts the accumulator based on direction and magnitude with wrap mod dialSize.
 func Apply(acc int, dir rune, magnitude int) int {
+	return applyOn(dialSize, acc, dir, magnitude)
+}
+
+// applyOn is Apply on a dial of the given size. The magnitude is reduced mod
+// size first so that acc+magnitude cannot overflow.
+func applyOn(size, acc int, dir rune, magnitude int) int {
 	if dir == 'L' {
-		return wrapDial(acc - magnitude)
+		return wrapOn(size, acc-magnitude%size)
 	}
-	return wrapDial(acc + magnitude)
+	return wrapOn(size, acc+magnitude%size)
 }
 
 // countZeroCrossings counts how many times a dial moving from acc by magnitude in dir
 // lands on 0 (including when it stops there). Movement is stepwise +/-1 with wrap.
 func countZeroCrossings(acc int, dir rune, magnitude int) int {
+	return countCrossings(dialSize, acc, dir, magnitude, 0)
+}
+
+// countCrossings counts how many times a dial of the given size moving from acc by
+// magnitude in dir lands on target. It shifts the dial so target sits at 0.
+func countCrossings(size, acc int, dir rune, magnitude, target int) int {
 	if magnitude <= 0 {
 		return 0
 	}
 
+	rel := wrapOn(size, acc-target)
 	base := 0
 	switch dir {
 	case 'L':
-		base = acc % dialSize
+		base = rel
 		if base == 0 {
-			base = dialSize
+			base = size
 		}
 	case 'R':
-		base = (dialSize - (acc % dialSize)) % dialSize
+		base = (size - rel) % size
 		if base == 0 {
-			base = dialSize
+			base = size
 		}
 	default:
 		return 0
@@ -44,5 +62,27 @@ func countZeroCrossings(acc int, dir rune, magnitude int) int {
 	if magnitude < base {
 		return 0
 	}
-	return 1 + (magnitude-base)/dialSize
+	return 1 + (magnitude-base)/size
+}
+
+// countWraps counts how many times a dial of the given size moving from acc by
+// magnitude in dir wraps around: R wraps when stepping from size-1 to 0, L when
+// stepping from 0 to size-1.
+func countWraps(size, acc int, dir rune, magnitude int) int {
+	if magnitude <= 0 {
+		return 0
+	}
+
+	switch dir {
+	case 'R':
+		// Split the magnitude so that acc+magnitude cannot overflow.
+		return magnitude/size + (acc+magnitude%size)/size
+	case 'L':
+		if magnitude <= acc {
+			return 0
+		}
+		return 1 + (magnitude-acc-1)/size
+	default:
+		return 0
+	}
 }
@@ -0,0 +1,138 @@
+package day1
+
+import (
+	"math"
+	"math/big"
+)
+
+// ParseBigToken validates a single token of the form `[L|R][0-9]+` without
+// limiting the magnitude to the int range.
+func ParseBigToken(token string) (rune, *big.Int, error) {
+	if len(token) < 2 {
+		return 0, nil, ErrInvalidMagnitude
+	}
+
+	dir := rune(token[0])
+	if dir != 'L' && dir != 'R' {
+		return 0, nil, ErrInvalidDirection
+	}
+
+	digits := token[1:]
+	for i := 0; i < len(digits); i++ {
+		if digits[i] < '0' || digits[i] > '9' {
+			return 0, nil, ErrInvalidMagnitude
+		}
+	}
+	mag, ok := new(big.Int).SetString(digits, 10)
+	if !ok || mag.Sign() <= 0 {
+		return 0, nil, ErrInvalidMagnitude
+	}
+
+	return dir, mag, nil
+}
+
+// RotateBig is Rotate for magnitudes of any size. A magnitude m = q*size + r
+// moves the dial like r does and hits every target q extra times.
+func (d *Dial) RotateBig(dir rune, magnitude *big.Int) (int, *big.Int, error) {
+	if magnitude.IsInt64() && magnitude.Int64() <= math.MaxInt {
+		pos, hits, err := d.Rotate(dir, int(magnitude.Int64()))
+		return pos, big.NewInt(int64(hits)), err
+	}
+	if dir != 'L' && dir != 'R' {
+		return d.position, nil, ErrInvalidDirection
+	}
+	if magnitude.Sign() <= 0 {
+		return d.position, nil, ErrInvalidMagnitude
+	}
+
+	q, r := new(big.Int).QuoRem(magnitude, big.NewInt(int64(d.size)), new(big.Int))
+	rem := int(r.Int64())
+
+	from := d.position
+	next := applyOn(d.size, from, dir, rem)
+	hits := new(big.Int)
+	stopped := false
+	for i := range d.targets {
+		n := new(big.Int).Add(q, big.NewInt(int64(countCrossings(d.size, from, dir, rem, d.targets[i].Position))))
+		d.crossings[i].addBig(n)
+		hits.Add(hits, n)
+		if next == d.targets[i].Position {
+			d.targets[i].Stops++
+			stopped = true
+		}
+	}
+	if d.hist != nil {
+		d.hist.rotateBig(from, dir, q, rem)
+	}
+	d.position = next
+	d.steps++
+
+	if d.onRotate != nil {
+		e := Event{
+			Step:         d.steps,
+			Dir:          dir,
+			BigMagnitude: new(big.Int).Set(magnitude),
+			From:         from,
+			To:           next,
+			Stopped:      stopped,
+		}
+		e.Crossings, e.BigCrossings = splitBig(hits)
+		d.onRotate(e)
+	}
+	return next, hits, nil
+}
+
+// tally is a counter that stays on int arithmetic until it would overflow.
+type tally struct {
+	small int
+	big   *big.Int
+}
+
+func (t *tally) add(n int) {
+	if t.big != nil {
+		t.big.Add(t.big, big.NewInt(int64(n)))
+		return
+	}
+	if t.small > math.MaxInt-n {
+		t.big = new(big.Int).Add(big.NewInt(int64(t.small)), big.NewInt(int64(n)))
+		return
+	}
+	t.small += n
+}
+
+func (t *tally) addBig(n *big.Int) {
+	if n.IsInt64() && n.Int64() <= math.MaxInt {
+		t.add(int(n.Int64()))
+		return
+	}
+	if t.big == nil {
+		t.big = big.NewInt(int64(t.small))
+	}
+	t.big.Add(t.big, n)
+}
+
+func (t *tally) addTally(o tally) {
+	if o.big != nil {
+		t.addBig(o.big)
+		return
+	}
+	t.add(o.small)
+}
+
+// split returns the count as an int, or math.MaxInt and the exact value once
+// it no longer fits.
+func (t tally) split() (int, *big.Int) {
+	if t.big == nil {
+		return t.small, nil
+	}
+	return splitBig(t.big)
+}
+
+// splitBig returns n as an int, or math.MaxInt and a copy of n when it does not
+// fit.
+func splitBig(n *big.Int) (int, *big.Int) {
+	if n.IsInt64() && n.Int64() <= math.MaxInt {
+		return int(n.Int64()), nil
+	}
+	return math.MaxInt, new(big.Int).Set(n)
+}
@@ -0,0 +1,134 @@
+package day1
+
+import "math/big"
+
+// Event describes a single rotation applied to a Dial.
+type Event struct {
+	// Step is the 1-based index of the rotation.
+	Step      int
+	Dir       rune
+	Magnitude int
+	// BigMagnitude is set instead of Magnitude when the magnitude overflows int.
+	BigMagnitude *big.Int
+	From         int
+	To           int
+	// Crossings counts target hits during the rotation, including the stop.
+	Crossings int
+	// BigCrossings holds the exact hit count when it overflows int, in which
+	// case Crossings is math.MaxInt.
+	BigCrossings *big.Int
+	// Stopped reports whether the rotation ended on a target.
+	Stopped bool
+}
+
+// Dial applies rotations one at a time on a configurable dial and keeps the
+// running counts that Compute reports.
+type Dial struct {
+	size     int
+	position int
+	steps    int
+	targets  []TargetCount
+	// crossings holds the exact per-target hit counts behind targets.
+	crossings []tally
+	// hist is nil unless Options.Histogram is set.
+	hist     *histogram
+	onRotate func(Event)
+}
+
+// NewDial returns a dial positioned at opts.Start. When opts.OnRotate is set it
+// is called after every rotation.
+func NewDial(opts Options) (*Dial, error) {
+	opts, err := opts.normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	targets := make([]TargetCount, len(opts.Targets))
+	for i, t := range opts.Targets {
+		targets[i].Position = t
+	}
+	d := &Dial{
+		size:      opts.DialSize,
+		position:  opts.Start,
+		targets:   targets,
+		crossings: make([]tally, len(targets)),
+		onRotate:  opts.OnRotate,
+	}
+	if opts.Histogram {
+		d.hist = newHistogram(opts.DialSize)
+	}
+	return d, nil
+}
+
+// Position returns the position the dial currently points at.
+func (d *Dial) Position() int {
+	return d.position
+}
+
+// Steps returns how many rotations have been applied.
+func (d *Dial) Steps() int {
+	return d.steps
+}
+
+// Rotate turns the dial by magnitude in dir and returns the new position along
+// with the number of target hits during this rotation.
+func (d *Dial) Rotate(dir rune, magnitude int) (int, int, error) {
+	if dir != 'L' && dir != 'R' {
+		return d.position, 0, ErrInvalidDirection
+	}
+	if magnitude <= 0 {
+		return d.position, 0, ErrInvalidMagnitude
+	}
+
+	from := d.position
+	next := applyOn(d.size, from, dir, magnitude)
+	hits := 0
+	stopped := false
+	for i := range d.targets {
+		n := countCrossings(d.size, from, dir, magnitude, d.targets[i].Position)
+		d.crossings[i].add(n)
+		hits += n
+		if next == d.targets[i].Position {
+			d.targets[i].Stops++
+			stopped = true
+		}
+	}
+	if d.hist != nil {
+		d.hist.rotate(from, dir, magnitude)
+	}
+	d.position = next
+	d.steps++
+
+	if d.onRotate != nil {
+		d.onRotate(Event{
+			Step:      d.steps,
+			Dir:       dir,
+			Magnitude: magnitude,
+			From:      from,
+			To:        next,
+			Crossings: hits,
+			Stopped:   stopped,
+		})
+	}
+	return next, hits, nil
+}
+
+// Result summarizes the rotations applied so far. Histogram is nil when it was
+// not requested or one of its counts overflowed int.
+func (d *Dial) Result() Result {
+	targets := make([]TargetCount, len(d.targets))
+	copy(targets, d.targets)
+
+	result := Result{Position: d.position, Targets: targets}
+	var total tally
+	for i := range targets {
+		targets[i].Crossings, targets[i].BigCrossings = d.crossings[i].split()
+		result.ZeroStops += targets[i].Stops
+		total.addTally(d.crossings[i])
+	}
+	result.ZeroCrossings, result.BigZeroCrossings = total.split()
+	if d.hist != nil {
+		result.Histogram = d.hist.counts()
+	}
+	return result
+}
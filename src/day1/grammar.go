@@ -0,0 +1,328 @@
+package day1
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"unicode"
+)
+
+var (
+	ErrUnbalancedGroup = errors.New("unbalanced repetition group")
+	ErrInvalidRepeat   = errors.New("invalid repetition count")
+)
+
+// The instruction grammar accepted by Compute extends the plain token list:
+//
+//	program     = { item } .
+//	item        = instruction | group .
+//	instruction = ( "L" | "R" ) digits .
+//	group       = "(" { item } ")" [ "x" digits ] .
+//
+// Items are separated by whitespace and/or commas, and `#` starts a comment
+// running to the end of the line. A group without a count runs once. Groups
+// are run rotation by rotation, so a group may not expand to more than
+// maxGroupRotations rotations, nested counts included.
+
+// maxGroupRotations bounds the number of rotations a single group expands to.
+const maxGroupRotations = 1 << 24
+
+type itemKind int
+
+const (
+	itemEOF itemKind = iota
+	itemWord
+	itemOpen
+	itemClose
+)
+
+type item struct {
+	kind itemKind
+	text string
+	// repeat holds the digits after `)x`, or "" when the group has no count.
+	repeat    string
+	line, col int
+}
+
+// lexer splits the input into items while tracking 1-based line and column
+// numbers (in runes) for error messages.
+type lexer struct {
+	r    *bufio.Reader
+	line int
+	col  int
+}
+
+func newLexer(r io.Reader) *lexer {
+	return &lexer{r: bufio.NewReader(r), line: 1, col: 1}
+}
+
+func (l *lexer) read() (rune, error) {
+	ch, _, err := l.r.ReadRune()
+	if err != nil {
+		return 0, err
+	}
+	if ch == '\n' {
+		l.line++
+		l.col = 1
+	} else {
+		l.col++
+	}
+	return ch, nil
+}
+
+func (l *lexer) unread(ch rune) {
+	l.r.UnreadRune()
+	if ch == '\n' {
+		l.line--
+		return
+	}
+	l.col--
+}
+
+func isDelimiter(ch rune) bool {
+	return unicode.IsSpace(ch) || ch == ',' || ch == '#' || ch == '(' || ch == ')'
+}
+
+// word reads runes up to the next delimiter.
+func (l *lexer) word() (string, error) {
+	var buf []rune
+	for {
+		ch, err := l.read()
+		if err == io.EOF {
+			return string(buf), nil
+		}
+		if err != nil {
+			return "", err
+		}
+		if isDelimiter(ch) {
+			l.unread(ch)
+			return string(buf), nil
+		}
+		buf = append(buf, ch)
+	}
+}
+
+// next returns the following item, skipping separators and comments.
+func (l *lexer) next() (item, error) {
+	for {
+		line, col := l.line, l.col
+		ch, err := l.read()
+		if err == io.EOF {
+			return item{kind: itemEOF, line: line, col: col}, nil
+		}
+		if err != nil {
+			return item{}, err
+		}
+
+		switch {
+		case unicode.IsSpace(ch) || ch == ',':
+			continue
+		case ch == '#':
+			for ch != '\n' {
+				if ch, err = l.read(); err == io.EOF {
+					break
+				} else if err != nil {
+					return item{}, err
+				}
+			}
+			continue
+		case ch == '(':
+			return item{kind: itemOpen, text: "(", line: line, col: col}, nil
+		case ch == ')':
+			it := item{kind: itemClose, text: ")", line: line, col: col}
+			next, err := l.read()
+			if err == io.EOF {
+				return it, nil
+			}
+			if err != nil {
+				return item{}, err
+			}
+			if next != 'x' {
+				l.unread(next)
+				return it, nil
+			}
+			if it.repeat, err = l.word(); err != nil {
+				return item{}, err
+			}
+			it.text = ")x" + it.repeat
+			if it.repeat == "" {
+				// Keep a non-empty marker so the parser reports the bad count.
+				it.repeat = "x"
+			}
+			return it, nil
+		default:
+			l.unread(ch)
+			text, err := l.word()
+			if err != nil {
+				return item{}, err
+			}
+			return item{kind: itemWord, text: text, line: line, col: col}, nil
+		}
+	}
+}
+
+// step is a parsed instruction or repetition group.
+type step interface {
+	apply(d *Dial) error
+}
+
+type instruction struct {
+	dir    rune
+	mag    int
+	bigMag *big.Int
+}
+
+func (in instruction) apply(d *Dial) error {
+	if in.bigMag != nil {
+		_, _, err := d.RotateBig(in.dir, in.bigMag)
+		return err
+	}
+	_, _, err := d.Rotate(in.dir, in.mag)
+	return err
+}
+
+type group struct {
+	body  []step
+	count int
+}
+
+func (g group) apply(d *Dial) error {
+	for i := 0; i < g.count; i++ {
+		for _, s := range g.body {
+			if err := s.apply(d); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// parseInstruction parses a single `[L|R][0-9]+` token of any magnitude.
+func parseInstruction(token string) (instruction, error) {
+	dir, mag, err := ParseToken(token)
+	if err == nil {
+		return instruction{dir: dir, mag: mag}, nil
+	}
+	if !errors.Is(err, ErrInvalidMagnitude) {
+		return instruction{}, err
+	}
+
+	dir, bigMag, err := ParseBigToken(token)
+	if err != nil {
+		return instruction{}, err
+	}
+	return instruction{dir: dir, bigMag: bigMag}, nil
+}
+
+func parseRepeat(text string) (int, error) {
+	if text == "" {
+		return 1, nil
+	}
+	for i := 0; i < len(text); i++ {
+		if text[i] < '0' || text[i] > '9' {
+			return 0, ErrInvalidRepeat
+		}
+	}
+	count, err := strconv.Atoi(text)
+	if err != nil || count <= 0 {
+		return 0, ErrInvalidRepeat
+	}
+	return count, nil
+}
+
+// syntaxError locates an error at the item where it was found.
+type syntaxError struct {
+	line, col int
+	text      string
+	err       error
+	// open is set when the input ended inside a group.
+	open bool
+}
+
+func errorAt(it item, err error) *syntaxError {
+	return &syntaxError{line: it.line, col: it.col, text: it.text, err: err}
+}
+
+func (e *syntaxError) Error() string {
+	return fmt.Sprintf("line %d col %d (%s): %v", e.line, e.col, e.text, e.err)
+}
+
+func (e *syntaxError) Unwrap() error { return e.err }
+
+// runProgram parses the instruction grammar from r and applies it to the dial.
+func runProgram(r io.Reader, dial *Dial) error {
+	return newLexer(r).run(dial)
+}
+
+// run parses the rest of the input and applies it to the dial. Top-level
+// instructions are applied as soon as they are read; groups are applied once
+// closed.
+func (l *lexer) run(dial *Dial) error {
+	type frame struct {
+		body []step
+		open item
+		// rotations counts the rotations one run of body expands to.
+		rotations int
+	}
+
+	var stack []frame
+	emit := func(s step, rotations int) error {
+		if len(stack) == 0 {
+			return s.apply(dial)
+		}
+		top := &stack[len(stack)-1]
+		top.body = append(top.body, s)
+		top.rotations += rotations
+		return nil
+	}
+
+	for {
+		it, err := l.next()
+		if err != nil {
+			return err
+		}
+
+		switch it.kind {
+		case itemEOF:
+			if len(stack) > 0 {
+				err := errorAt(stack[len(stack)-1].open, ErrUnbalancedGroup)
+				err.open = true
+				return err
+			}
+			return nil
+		case itemWord:
+			in, err := parseInstruction(it.text)
+			if err != nil {
+				return errorAt(it, err)
+			}
+			if err := emit(in, 1); err != nil {
+				return errorAt(it, err)
+			}
+		case itemOpen:
+			stack = append(stack, frame{open: it})
+		case itemClose:
+			if len(stack) == 0 {
+				return errorAt(it, ErrUnbalancedGroup)
+			}
+			count, err := parseRepeat(it.repeat)
+			if err != nil {
+				return errorAt(it, err)
+			}
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if top.rotations == 0 {
+				// An empty group does nothing however often it repeats.
+				continue
+			}
+			if top.rotations > maxGroupRotations/count {
+				return errorAt(it, fmt.Errorf("%w: group expands to more than %d rotations", ErrInvalidRepeat, maxGroupRotations))
+			}
+			if err := emit(group{body: top.body, count: count}, top.rotations*count); err != nil {
+				return errorAt(it, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package day1
+
+import (
+	"errors"
+	"math"
+	"math/big"
+)
+
+var ErrHistogramOverflow = errors.New("histogram count overflow")
+
+// histogram counts how many clicks landed on each dial position. A rotation of
+// magnitude m = q*size + r gives every position q clicks plus one click on the
+// r positions after the start, so each rotation is O(1): complete turns go to
+// turns and the partial arc is recorded in a difference array.
+type histogram struct {
+	size     int
+	turns    int
+	diff     []int
+	overflow bool
+}
+
+func newHistogram(size int) *histogram {
+	return &histogram{size: size, diff: make([]int, size+1)}
+}
+
+// rotate records a rotation of magnitude in dir starting from from.
+func (h *histogram) rotate(from int, dir rune, magnitude int) {
+	h.addTurns(magnitude / h.size)
+	h.addArc(from, dir, magnitude%h.size)
+}
+
+// rotateBig records a rotation of q complete turns plus rem clicks.
+func (h *histogram) rotateBig(from int, dir rune, q *big.Int, rem int) {
+	if !q.IsInt64() || q.Int64() > math.MaxInt {
+		h.overflow = true
+		return
+	}
+	h.addTurns(int(q.Int64()))
+	h.addArc(from, dir, rem)
+}
+
+func (h *histogram) addTurns(n int) {
+	if h.turns > math.MaxInt-n {
+		h.overflow = true
+		return
+	}
+	h.turns += n
+}
+
+// addArc adds one click to the length positions visited after from, with
+// length < size.
+func (h *histogram) addArc(from int, dir rune, length int) {
+	if length == 0 {
+		return
+	}
+
+	start := wrapOn(h.size, from+1)
+	if dir == 'L' {
+		start = wrapOn(h.size, from-length)
+	}
+	end := start + length
+	h.diff[start]++
+	if end <= h.size {
+		h.diff[end]--
+		return
+	}
+	h.diff[h.size]--
+	h.diff[0]++
+	h.diff[end-h.size]--
+}
+
+// counts returns the clicks per position, or nil when a count overflowed.
+func (h *histogram) counts() []int {
+	if h.overflow {
+		return nil
+	}
+
+	res := make([]int, h.size)
+	arc := 0
+	for pos := range res {
+		arc += h.diff[pos]
+		if h.turns > math.MaxInt-arc {
+			return nil
+		}
+		res[pos] = h.turns + arc
+	}
+	return res
+}
@@ -0,0 +1,155 @@
+package day1
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+)
+
+var ErrInvalidWheel = errors.New("invalid wheel")
+
+// OdometerResult reports the state of every wheel of an Odometer.
+type OdometerResult struct {
+	// Wheels holds one Result per wheel, wheel 1 first.
+	Wheels []Result
+	// Overflow is the net number of carries out of the last wheel; borrows
+	// count as negative.
+	Overflow int
+}
+
+// Odometer is a stack of dials where every full wrap of one wheel turns the
+// next wheel by one click in the same direction, like an odometer or a
+// combination lock.
+type Odometer struct {
+	wheels   []*Dial
+	overflow int
+}
+
+// NewOdometer returns an odometer of the given number of wheels, each using the
+// geometry, start position and targets of opts. opts.OnRotate is ignored.
+func NewOdometer(wheels int, opts Options) (*Odometer, error) {
+	if wheels < 1 {
+		return nil, fmt.Errorf("%w: %d wheels", ErrInvalidOptions, wheels)
+	}
+
+	opts.OnRotate = nil
+	o := &Odometer{wheels: make([]*Dial, wheels)}
+	for i := range o.wheels {
+		d, err := NewDial(opts)
+		if err != nil {
+			return nil, err
+		}
+		o.wheels[i] = d
+	}
+	return o, nil
+}
+
+// Rotate turns the 1-based wheel by magnitude in dir and propagates the
+// resulting carries to the following wheels.
+func (o *Odometer) Rotate(wheel int, dir rune, magnitude int) error {
+	if wheel < 1 || wheel > len(o.wheels) {
+		return fmt.Errorf("%w: %d", ErrInvalidWheel, wheel)
+	}
+	if dir != 'L' && dir != 'R' {
+		return ErrInvalidDirection
+	}
+	if magnitude <= 0 {
+		return ErrInvalidMagnitude
+	}
+
+	for i := wheel - 1; magnitude > 0; i++ {
+		if i == len(o.wheels) {
+			if dir == 'R' {
+				o.overflow += magnitude
+			} else {
+				o.overflow -= magnitude
+			}
+			return nil
+		}
+		d := o.wheels[i]
+		carry := countWraps(d.size, d.position, dir, magnitude)
+		if _, _, err := d.Rotate(dir, magnitude); err != nil {
+			return err
+		}
+		magnitude = carry
+	}
+	return nil
+}
+
+// Positions returns the current position of every wheel, wheel 1 first.
+func (o *Odometer) Positions() []int {
+	positions := make([]int, len(o.wheels))
+	for i, d := range o.wheels {
+		positions[i] = d.Position()
+	}
+	return positions
+}
+
+// Result summarizes every wheel.
+func (o *Odometer) Result() OdometerResult {
+	res := OdometerResult{Wheels: make([]Result, len(o.wheels)), Overflow: o.overflow}
+	for i, d := range o.wheels {
+		res.Wheels[i] = d.Result()
+	}
+	return res
+}
+
+// ParseWheelToken validates a single token of the form `[0-9]*[L|R][0-9]+`. The
+// leading number names the 1-based wheel and defaults to 1 when omitted.
+func ParseWheelToken(token string) (int, rune, int, error) {
+	i := 0
+	wheel := 0
+	for i < len(token) && token[i] >= '0' && token[i] <= '9' {
+		wheel = wheel*10 + int(token[i]-'0')
+		if wheel > 1<<20 {
+			return 0, 0, 0, ErrInvalidWheel
+		}
+		i++
+	}
+	if i == 0 {
+		wheel = 1
+	} else if wheel == 0 {
+		return 0, 0, 0, ErrInvalidWheel
+	}
+
+	dir, mag, err := ParseToken(token[i:])
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	return wheel, dir, mag, nil
+}
+
+// ComputeOdometer parses wheel tokens from the reader and applies them to an
+// odometer of the given number of wheels.
+func ComputeOdometer(r io.Reader, wheels int, opts Options) (OdometerResult, error) {
+	o, err := NewOdometer(wheels, opts)
+	if err != nil {
+		return OdometerResult{}, err
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 1024), 1024*1024)
+	scanner.Split(bufio.ScanWords)
+
+	position := 0
+	for scanner.Scan() {
+		position++
+		token := scanner.Text()
+		wheel, dir, mag, err := ParseWheelToken(token)
+		if err != nil {
+			return OdometerResult{}, fmt.Errorf("token %d (%s): %w", position, token, err)
+		}
+		if err := o.Rotate(wheel, dir, mag); err != nil {
+			return OdometerResult{}, fmt.Errorf("token %d (%s): %w", position, token, err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return OdometerResult{}, err
+	}
+	if position == 0 {
+		return OdometerResult{}, ErrNoInstructions
+	}
+	return o.Result(), nil
+}
@@ -0,0 +1,54 @@
+package day1
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidOptions = errors.New("invalid options")
+
+// Options describes the dial geometry used by ComputeWith and NewDial.
+type Options struct {
+	// DialSize is the number of positions on the dial (0..DialSize-1).
+	DialSize int
+	// Start is the position the dial points at before the first rotation.
+	Start int
+	// Targets lists the positions whose hits are counted. Empty means {0}.
+	Targets []int
+	// OnRotate, when set, receives an Event after every rotation.
+	OnRotate func(Event)
+	// Histogram requests Result.Histogram, the clicks landing on each position.
+	Histogram bool
+}
+
+// DefaultOptions returns the puzzle geometry: a 100-position dial starting at
+// InitialValue and counting hits on 0.
+func DefaultOptions() Options {
+	return Options{DialSize: dialSize, Start: InitialValue, Targets: []int{0}}
+}
+
+// normalize validates the options and fills in the default target.
+func (o Options) normalize() (Options, error) {
+	if o.DialSize < 1 {
+		return Options{}, fmt.Errorf("%w: dial size %d", ErrInvalidOptions, o.DialSize)
+	}
+	if o.Start < 0 || o.Start >= o.DialSize {
+		return Options{}, fmt.Errorf("%w: start %d outside dial of size %d", ErrInvalidOptions, o.Start, o.DialSize)
+	}
+	if len(o.Targets) == 0 {
+		o.Targets = []int{0}
+		return o, nil
+	}
+
+	seen := make(map[int]struct{}, len(o.Targets))
+	for _, t := range o.Targets {
+		if t < 0 || t >= o.DialSize {
+			return Options{}, fmt.Errorf("%w: target %d outside dial of size %d", ErrInvalidOptions, t, o.DialSize)
+		}
+		if _, dup := seen[t]; dup {
+			return Options{}, fmt.Errorf("%w: duplicate target %d", ErrInvalidOptions, t)
+		}
+		seen[t] = struct{}{}
+	}
+	return o, nil
+}
@@ -0,0 +1,408 @@
+package day1
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"runtime"
+	"sync"
+)
+
+// minChunkSize keeps small inputs from being split into chunks that cost more
+// to schedule than to scan.
+const minChunkSize = 1 << 20
+
+// denseDialLimit is the largest dial whose chunks are summarized position by
+// position. Larger dials would cost several ints per position and chunk.
+const denseDialLimit = 1 << 12
+
+// chunkSummary describes a chunk evaluated from position 0. Because rotations
+// compose as offsets mod the dial size, a chunk starting at s hits position p
+// exactly as often as the summary's relative position p-s, so one pass per
+// chunk is enough whatever its real start turns out to be. On dials larger
+// than denseDialLimit only steps, offset and the end position are kept.
+type chunkSummary struct {
+	// from and to delimit the chunk in the input.
+	from, to int64
+	steps    int
+	offset   int
+	// clicks counts the clicks landing on each relative position.
+	clicks []int
+	// stops counts the rotations ending on each relative position.
+	stops []int
+	// overflow is set when clicks could not be represented as ints.
+	overflow bool
+	// endLine and endCol locate the end of the chunk relative to its start,
+	// counted like the lexer's line and column.
+	endLine, endCol int
+	err             error
+}
+
+// ComputeParallel is ComputeWith for a seekable input of the given size. The
+// input is split into whitespace-aligned chunks which are summarized
+// concurrently by workers goroutines (runtime.NumCPU() when workers <= 0) and
+// then stitched together in order. Chunk bounds never fall inside a comment;
+// a chunk ending inside a group is evaluated again together with the chunks
+// that follow until the group closes. Errors are located in the whole input,
+// so they read exactly as ComputeWith's. Only when a chunk's click counts
+// overflow is the input evaluated by ComputeWith instead. opts.OnRotate
+// cannot be honoured out of order and is rejected.
+//
+// Up to denseDialLimit positions, every chunk is summarized for all positions
+// at once, holding O(opts.DialSize) ints per chunk. Larger dials are read
+// twice instead: once for the offset of every chunk, then once more from the
+// position each chunk really starts at, so memory stays O(len(opts.Targets))
+// per worker, plus O(opts.DialSize) per worker when opts.Histogram is set.
+func ComputeParallel(r io.ReaderAt, size int64, opts Options, workers int) (Result, error) {
+	if opts.OnRotate != nil {
+		return Result{}, fmt.Errorf("%w: OnRotate is not supported in parallel mode", ErrInvalidOptions)
+	}
+	opts, err := opts.normalize()
+	if err != nil {
+		return Result{}, err
+	}
+
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	chunkCount := int64(workers) * 4
+	if limit := size / minChunkSize; chunkCount > limit {
+		chunkCount = limit
+	}
+	if chunkCount < 1 {
+		chunkCount = 1
+	}
+
+	bounds, err := chunkBounds(r, size, chunkCount)
+	if err != nil {
+		return Result{}, err
+	}
+
+	dense := opts.DialSize <= denseDialLimit
+	summaries := make([]chunkSummary, len(bounds)-1)
+	jobCh := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for c := range jobCh {
+				section := io.NewSectionReader(r, bounds[c], bounds[c+1]-bounds[c])
+				summaries[c] = summarizeChunk(section, opts.DialSize, dense)
+			}
+		}()
+	}
+	for c := range summaries {
+		jobCh <- c
+	}
+	close(jobCh)
+	wg.Wait()
+
+	steps := 0
+	line, col := 1, 1
+	merged := summaries[:0]
+	for c := 0; c < len(summaries); {
+		s, end := summaries[c], c+1
+		for span := 2; opensGroup(s.err) && end < len(summaries); span *= 2 {
+			end = min(c+span, len(summaries))
+			section := io.NewSectionReader(r, bounds[c], bounds[end]-bounds[c])
+			s = summarizeChunk(section, opts.DialSize, dense)
+		}
+		if s.err != nil {
+			return Result{}, relocate(s.err, line, col)
+		}
+		if s.overflow {
+			return ComputeWith(io.NewSectionReader(r, 0, size), opts)
+		}
+		if s.endLine > 1 {
+			line, col = line+s.endLine-1, s.endCol
+		} else {
+			col += s.endCol - 1
+		}
+		s.from, s.to = bounds[c], bounds[end]
+		steps += s.steps
+		merged = append(merged, s)
+		c = end
+	}
+	if steps == 0 {
+		return Result{}, ErrNoInstructions
+	}
+
+	if !dense {
+		return replayChunks(r, merged, opts, workers)
+	}
+	return stitchChunks(merged, opts)
+}
+
+// opensGroup reports whether err is a chunk ending inside a group, which may
+// close in a later chunk.
+func opensGroup(err error) bool {
+	var se *syntaxError
+	return errors.As(err, &se) && se.open
+}
+
+// relocate shifts a syntax error found in a chunk starting at line, col to
+// its position in the whole input.
+func relocate(err error, line, col int) error {
+	var se *syntaxError
+	if !errors.As(err, &se) {
+		return err
+	}
+	moved := *se
+	if moved.line == 1 {
+		moved.col += col - 1
+	}
+	moved.line += line - 1
+	return &moved
+}
+
+// stitchChunks walks the summaries in order, shifting each one by the
+// position its chunk really starts from.
+func stitchChunks(summaries []chunkSummary, opts Options) (Result, error) {
+	n := opts.DialSize
+	targets := make([]TargetCount, len(opts.Targets))
+	crossings := make([]tally, len(opts.Targets))
+	for i, t := range opts.Targets {
+		targets[i].Position = t
+	}
+	var hist []int
+	if opts.Histogram {
+		hist = make([]int, n)
+	}
+
+	start := opts.Start
+	for _, s := range summaries {
+		if s.steps == 0 {
+			continue
+		}
+		for i := range targets {
+			rel := wrapOn(n, targets[i].Position-start)
+			crossings[i].add(s.clicks[rel])
+			targets[i].Stops += s.stops[rel]
+		}
+		for p := range hist {
+			c := s.clicks[wrapOn(n, p-start)]
+			if hist[p] > math.MaxInt-c {
+				return Result{}, ErrHistogramOverflow
+			}
+			hist[p] += c
+		}
+		start = wrapOn(n, start+s.offset)
+	}
+	return assemble(start, targets, crossings, hist), nil
+}
+
+// replayChunks evaluates every chunk again from the position it really starts
+// at, tracking only the targets, and folds each result in as soon as it is
+// ready.
+func replayChunks(r io.ReaderAt, summaries []chunkSummary, opts Options, workers int) (Result, error) {
+	starts := make([]int, len(summaries))
+	start := opts.Start
+	for i, s := range summaries {
+		starts[i] = start
+		start = wrapOn(opts.DialSize, start+s.offset)
+	}
+
+	targets := make([]TargetCount, len(opts.Targets))
+	crossings := make([]tally, len(opts.Targets))
+	for i, t := range opts.Targets {
+		targets[i].Position = t
+	}
+	var hist []int
+	if opts.Histogram {
+		hist = make([]int, opts.DialSize)
+	}
+
+	var mu sync.Mutex
+	var firstErr error
+	fold := func(res Result) error {
+		for i, t := range res.Targets {
+			targets[i].Stops += t.Stops
+			if t.BigCrossings != nil {
+				crossings[i].addBig(t.BigCrossings)
+			} else {
+				crossings[i].add(t.Crossings)
+			}
+		}
+		if hist == nil {
+			return nil
+		}
+		if res.Histogram == nil {
+			return ErrHistogramOverflow
+		}
+		for p, c := range res.Histogram {
+			if hist[p] > math.MaxInt-c {
+				return ErrHistogramOverflow
+			}
+			hist[p] += c
+		}
+		return nil
+	}
+
+	jobCh := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for c := range jobCh {
+				s := summaries[c]
+				dial, err := NewDial(Options{DialSize: opts.DialSize, Start: starts[c], Targets: opts.Targets, Histogram: opts.Histogram})
+				if err == nil {
+					err = runProgram(io.NewSectionReader(r, s.from, s.to-s.from), dial)
+				}
+
+				mu.Lock()
+				if err == nil {
+					err = fold(dial.Result())
+				}
+				if err != nil && firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for c := range summaries {
+		jobCh <- c
+	}
+	close(jobCh)
+	wg.Wait()
+
+	if firstErr != nil {
+		return Result{}, firstErr
+	}
+	return assemble(start, targets, crossings, hist), nil
+}
+
+// assemble builds the Result of a parallel run from its per-target counts.
+func assemble(position int, targets []TargetCount, crossings []tally, hist []int) Result {
+	result := Result{Position: position, Targets: targets, Histogram: hist}
+	var total tally
+	for i := range targets {
+		targets[i].Crossings, targets[i].BigCrossings = crossings[i].split()
+		result.ZeroStops += targets[i].Stops
+		total.addTally(crossings[i])
+	}
+	result.ZeroCrossings, result.BigZeroCrossings = total.split()
+	return result
+}
+
+// summarizeChunk evaluates a chunk on a dial of the given size starting at 0.
+// Unless dense is set, only the steps, offset and end position are kept.
+func summarizeChunk(r io.Reader, size int, dense bool) chunkSummary {
+	var summary chunkSummary
+	opts := Options{DialSize: size}
+	if dense {
+		summary.stops = make([]int, size)
+		opts.Histogram = true
+		opts.OnRotate = func(e Event) { summary.stops[e.To]++ }
+	}
+	dial, err := NewDial(opts)
+	if err != nil {
+		summary.err = err
+		return summary
+	}
+
+	lex := newLexer(r)
+	if err := lex.run(dial); err != nil {
+		summary.err = err
+		return summary
+	}
+
+	summary.endLine, summary.endCol = lex.line, lex.col
+	summary.steps = dial.Steps()
+	summary.offset = dial.Position()
+	if dense {
+		summary.clicks = dial.Result().Histogram
+		summary.overflow = summary.clicks == nil
+	}
+	return summary
+}
+
+// chunkBounds returns count+1 offsets splitting [0,size) into chunks. Every
+// inner bound is moved forward onto a whitespace byte so no token is cut, and
+// past the end of the line when that byte is inside a comment.
+func chunkBounds(r io.ReaderAt, size, count int64) ([]int64, error) {
+	bounds := make([]int64, 0, count+1)
+	bounds = append(bounds, 0)
+	buf := make([]byte, 4096)
+
+	for i := int64(1); i < count; i++ {
+		pos := size * i / count
+		prev := bounds[len(bounds)-1]
+		if pos < prev {
+			pos = prev
+		}
+		pos, err := seekByte(r, pos, size, buf, isASCIISpace)
+		if err != nil {
+			return nil, err
+		}
+		comment, err := inComment(r, prev, pos, buf)
+		if err != nil {
+			return nil, err
+		}
+		if comment {
+			if pos, err = seekByte(r, pos, size, buf, isNewline); err != nil {
+				return nil, err
+			}
+		}
+		bounds = append(bounds, pos)
+	}
+	return append(bounds, size), nil
+}
+
+// seekByte returns the offset of the first byte at or after pos matching
+// match, or size when there is none.
+func seekByte(r io.ReaderAt, pos, size int64, buf []byte, match func(byte) bool) (int64, error) {
+	for pos < size {
+		n, err := r.ReadAt(buf, pos)
+		for j := 0; j < n; j++ {
+			if match(buf[j]) {
+				return pos + int64(j), nil
+			}
+		}
+		pos += int64(n)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+	return min(pos, size), nil
+}
+
+// inComment reports whether the byte at pos is inside a `#` comment. It looks
+// back no further than from, which must itself lie outside a comment.
+func inComment(r io.ReaderAt, from, pos int64, buf []byte) (bool, error) {
+	for pos > from {
+		n := min(int64(len(buf)), pos-from)
+		if read, err := r.ReadAt(buf[:n], pos-n); int64(read) < n {
+			return false, err
+		}
+		for j := n - 1; j >= 0; j-- {
+			switch buf[j] {
+			case '\n':
+				return false, nil
+			case '#':
+				return true, nil
+			}
+		}
+		pos -= n
+	}
+	return false, nil
+}
+
+func isNewline(b byte) bool {
+	return b == '\n'
+}
+
+func isASCIISpace(b byte) bool {
+	switch b {
+	case ' ', '\t', '\n', '\v', '\f', '\r':
+		return true
+	}
+	return false
+}
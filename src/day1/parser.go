@@ -1,10 +1,9 @@
 package day1
 
 import (
-	"bufio"
 	"errors"
-	"fmt"
 	"io"
+	"math/big"
 	"strconv"
 )
 
@@ -17,11 +16,33 @@ var (
 const InitialValue = 50
 
 type Result struct {
-	Position      int
+	Position int
+	// ZeroStops counts rotations that end with the dial on a target (part 1).
+	ZeroStops int
+	// ZeroCrossings counts every click that lands on a target, mid-rotation included (part 2).
 	ZeroCrossings int
+	// BigZeroCrossings holds the exact count when it overflows int, in which case
+	// ZeroCrossings is math.MaxInt.
+	BigZeroCrossings *big.Int
+	// Targets breaks both counts down per target, in Options.Targets order.
+	Targets []TargetCount
+	// Histogram counts, for every dial position, the clicks that passed or
+	// stopped on it. Histogram[t] is the crossing count target t would get.
+	// It is only filled when Options.Histogram is set.
+	Histogram []int
 }
 
-// ParseToken validates a single token of the form `[L|R][0-9]+`.
+// TargetCount holds the hits recorded for a single target position.
+type TargetCount struct {
+	Position  int
+	Stops     int
+	Crossings int
+	// BigCrossings holds the exact count when Crossings overflows int.
+	BigCrossings *big.Int
+}
+
+// ParseToken validates a single token of the form `[L|R][0-9]+`. Magnitudes
+// beyond the int range are rejected; ParseBigToken accepts them.
 func ParseToken(token string) (rune, int, error) {
 	if len(token) < 2 {
 		return 0, 0, ErrInvalidMagnitude
@@ -40,37 +61,32 @@ func ParseToken(token string) (rune, int, error) {
 	return dir, mag, nil
 }
 
-// Compute parses tokens from the reader and applies them to the accumulator.
-// Returns an error with token position context on invalid input.
-// Tracks how many times the dial passes through 0 while applying instructions.
+// Compute parses instructions from the reader and applies them to the accumulator.
+// Returns an error with line and column context on invalid input.
+// Tracks how many rotations stop on 0 and how many times the dial passes through 0
+// while applying instructions. See grammar.go for the accepted syntax.
 func Compute(r io.Reader) (Result, error) {
-	scanner := bufio.NewScanner(r)
-	// Increase buffer in case of large magnitudes.
-	scanner.Buffer(make([]byte, 0, 1024), 1024*1024)
-	scanner.Split(bufio.ScanWords)
-
-	position := 0
-	acc := InitialValue
-	sawAny := false
-	totalZeroCrossings := 0
+	return ComputeWith(r, DefaultOptions())
+}
 
-	for scanner.Scan() {
-		position++
-		token := scanner.Text()
-		dir, mag, err := ParseToken(token)
-		if err != nil {
-			return Result{}, fmt.Errorf("token %d (%s): %w", position, token, err)
-		}
-		totalZeroCrossings += countZeroCrossings(acc, dir, mag)
-		acc = Apply(acc, dir, mag)
-		sawAny = true
+// ComputeWith is Compute on the dial geometry described by opts. Hits on any of
+// opts.Targets count towards ZeroStops and ZeroCrossings.
+func ComputeWith(r io.Reader, opts Options) (Result, error) {
+	dial, err := NewDial(opts)
+	if err != nil {
+		return Result{}, err
 	}
 
-	if err := scanner.Err(); err != nil {
+	if err := runProgram(r, dial); err != nil {
 		return Result{}, err
 	}
-	if !sawAny {
+	if dial.Steps() == 0 {
 		return Result{}, ErrNoInstructions
 	}
-	return Result{Position: acc, ZeroCrossings: totalZeroCrossings}, nil
+
+	result := dial.Result()
+	if opts.Histogram && result.Histogram == nil {
+		return Result{}, ErrHistogramOverflow
+	}
+	return result, nil
 }
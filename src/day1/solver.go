@@ -0,0 +1,31 @@
+package day1
+
+import (
+	"fmt"
+	"io"
+
+	"adventofcode2025/day1/src/aoc"
+)
+
+func init() {
+	aoc.Register(1, aoc.Parts{1: solvePart1, 2: solvePart2})
+}
+
+func solvePart1(r io.Reader) (string, error) {
+	result, err := Compute(r)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d", result.ZeroStops), nil
+}
+
+func solvePart2(r io.Reader) (string, error) {
+	result, err := Compute(r)
+	if err != nil {
+		return "", err
+	}
+	if result.BigZeroCrossings != nil {
+		return result.BigZeroCrossings.String(), nil
+	}
+	return fmt.Sprintf("%d", result.ZeroCrossings), nil
+}
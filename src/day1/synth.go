@@ -0,0 +1,119 @@
+package day1
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrInvalidPosition = errors.New("invalid dial position")
+
+// CrossingGoal selects how Synthesize chooses between the two directions that
+// reach the next target.
+type CrossingGoal int
+
+const (
+	// FewestClicks picks the direction with the smaller magnitude.
+	FewestClicks CrossingGoal = iota
+	// MinCrossings picks the direction passing through 0 (or the counted
+	// targets) the fewest times.
+	MinCrossings
+	// MaxCrossings picks the direction passing through 0 (or the counted
+	// targets) the most times.
+	MaxCrossings
+)
+
+// Instruction is a single rotation token.
+type Instruction struct {
+	Dir       rune
+	Magnitude int
+}
+
+// String renders the instruction in the `[L|R][0-9]+` input format.
+func (in Instruction) String() string {
+	return fmt.Sprintf("%c%d", in.Dir, in.Magnitude)
+}
+
+// FormatInstructions renders instructions as a space-separated input that
+// Compute accepts.
+func FormatInstructions(ins []Instruction) string {
+	parts := make([]string, len(ins))
+	for i, in := range ins {
+		parts[i] = in.String()
+	}
+	return strings.Join(parts, " ")
+}
+
+// Synthesize returns the shortest instruction list that moves the dial from
+// start through each of targets in order. A target equal to the current
+// position needs no rotation, every other target takes exactly one rotation
+// of less than a full turn, so the list length is minimal. goal decides
+// between turning left or right; ties fall back to the fewest clicks, then
+// to R.
+func Synthesize(start int, targets []int, goal CrossingGoal) ([]Instruction, error) {
+	opts := DefaultOptions()
+	opts.Start = start
+	return SynthesizeWith(opts, targets, goal)
+}
+
+// SynthesizeWith is Synthesize on the dial described by opts: the rotations
+// start from opts.Start on a dial of opts.DialSize positions, and crossings
+// are counted on opts.Targets rather than on 0 alone. The stops to visit are
+// given separately.
+func SynthesizeWith(opts Options, stops []int, goal CrossingGoal) ([]Instruction, error) {
+	if opts.DialSize >= 1 && (opts.Start < 0 || opts.Start >= opts.DialSize) {
+		return nil, fmt.Errorf("%w: start %d", ErrInvalidPosition, opts.Start)
+	}
+	opts, err := opts.normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	size := opts.DialSize
+	var ins []Instruction
+	acc := opts.Start
+	for i, stop := range stops {
+		if stop < 0 || stop >= size {
+			return nil, fmt.Errorf("target %d: %w: %d", i+1, ErrInvalidPosition, stop)
+		}
+		if stop == acc {
+			continue
+		}
+
+		left := Instruction{Dir: 'L', Magnitude: wrapOn(size, acc-stop)}
+		right := Instruction{Dir: 'R', Magnitude: wrapOn(size, stop-acc)}
+		next := pickInstruction(opts, acc, left, right, goal)
+		acc = applyOn(size, acc, next.Dir, next.Magnitude)
+		ins = append(ins, next)
+	}
+	return ins, nil
+}
+
+// pickInstruction chooses between the left and right rotations from acc.
+func pickInstruction(opts Options, acc int, left, right Instruction, goal CrossingGoal) Instruction {
+	lc, rc := 0, 0
+	for _, t := range opts.Targets {
+		lc += countCrossings(opts.DialSize, acc, left.Dir, left.Magnitude, t)
+		rc += countCrossings(opts.DialSize, acc, right.Dir, right.Magnitude, t)
+	}
+	switch goal {
+	case MinCrossings:
+		if lc != rc {
+			if lc < rc {
+				return left
+			}
+			return right
+		}
+	case MaxCrossings:
+		if lc != rc {
+			if lc > rc {
+				return left
+			}
+			return right
+		}
+	}
+	if left.Magnitude < right.Magnitude {
+		return left
+	}
+	return right
+}
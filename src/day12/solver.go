@@ -0,0 +1,20 @@
+package day12
+
+import (
+	"fmt"
+	"io"
+
+	"adventofcode2025/day1/src/aoc"
+)
+
+func init() {
+	aoc.Register(12, aoc.Parts{1: solvePart1})
+}
+
+func solvePart1(r io.Reader) (string, error) {
+	result, err := Compute(r)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d", result.FittingRegions), nil
+}
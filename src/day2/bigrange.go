@@ -0,0 +1,299 @@
+package day2
+
+import (
+	"fmt"
+	"io"
+	"iter"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+// bigRange is an inclusive range whose bounds may not fit in int64.
+type bigRange struct {
+	Start *big.Int
+	End   *big.Int
+}
+
+// BigTotals holds the number of distinct invalid IDs and their sum for inputs
+// of any size.
+type BigTotals struct {
+	Count *big.Int
+	Sum   *big.Int
+}
+
+// ComputeBigStream is ComputeStream for ranges whose bounds may exceed int64.
+func ComputeBigStream(r io.Reader, opts Options) (iter.Seq[*big.Int], error) {
+	rule, ranges, err := loadBigRanges(r, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(yield func(*big.Int) bool) {
+		for _, rg := range ranges {
+			for id := range invalidInBigRange(rg.Start, rg.End, rule) {
+				if !yield(id) {
+					return
+				}
+			}
+		}
+	}, nil
+}
+
+// ComputeBigTotals is ComputeTotals for ranges whose bounds may exceed int64.
+// Inputs that fit in int64 take the int64 path.
+func ComputeBigTotals(r io.Reader, opts Options) (BigTotals, error) {
+	rule, ranges, err := loadBigRanges(r, opts)
+	if err != nil {
+		return BigTotals{}, err
+	}
+
+	totals := BigTotals{Count: new(big.Int), Sum: new(big.Int)}
+	if small, ok := fitInt64(ranges); ok {
+		for _, rg := range small {
+			count, sum := totalsInRange(rg.Start, rg.End, rule)
+			totals.Count.Add(totals.Count, big.NewInt(count))
+			totals.Sum.Add(totals.Sum, sum)
+		}
+		return totals, nil
+	}
+
+	for _, rg := range ranges {
+		count, sum := totalsInBigRange(rg.Start, rg.End, rule)
+		totals.Count.Add(totals.Count, count)
+		totals.Sum.Add(totals.Sum, sum)
+	}
+	return totals, nil
+}
+
+// loadBigRanges is loadRanges with bounds of any size.
+func loadBigRanges(r io.Reader, opts Options) (Rule, []bigRange, error) {
+	rule, ranges, err := readBigRanges(r, opts)
+	if err != nil {
+		return Rule{}, nil, err
+	}
+	return rule, mergeBigRanges(ranges), nil
+}
+
+// readBigRanges reads the input and returns the rule together with the ranges
+// in input order, overlaps included.
+func readBigRanges(r io.Reader, opts Options) (Rule, []bigRange, error) {
+	rule, err := opts.rule()
+	if err != nil {
+		return Rule{}, nil, err
+	}
+
+	payload, err := io.ReadAll(r)
+	if err != nil {
+		return Rule{}, nil, err
+	}
+
+	ranges, err := parseRanges(string(payload), rule.base())
+	if err != nil {
+		return Rule{}, nil, err
+	}
+	if len(ranges) == 0 {
+		return Rule{}, nil, ErrNoRanges
+	}
+	return rule, ranges, nil
+}
+
+// fitInt64 converts the ranges to int64 bounds when they all fit.
+func fitInt64(ranges []bigRange) ([]valueRange, bool) {
+	res := make([]valueRange, len(ranges))
+	for i, rg := range ranges {
+		if !rg.Start.IsInt64() || !rg.End.IsInt64() {
+			return nil, false
+		}
+		res[i] = valueRange{Start: rg.Start.Int64(), End: rg.End.Int64()}
+	}
+	return res, true
+}
+
+// mergeBigRanges sorts the ranges and merges those that overlap or touch.
+func mergeBigRanges(ranges []bigRange) []bigRange {
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i].Start.Cmp(ranges[j].Start) < 0 })
+
+	one := big.NewInt(1)
+	merged := ranges[:1]
+	for _, rg := range ranges[1:] {
+		last := &merged[len(merged)-1]
+		if rg.Start.Cmp(new(big.Int).Add(last.End, one)) <= 0 {
+			if rg.End.Cmp(last.End) > 0 {
+				last.End = rg.End
+			}
+			continue
+		}
+		merged = append(merged, rg)
+	}
+	return merged
+}
+
+func parseBigBoundary(part string, base int) (*big.Int, error) {
+	text := strings.TrimSpace(part)
+	value, ok := new(big.Int).SetString(text, base)
+	if !ok || value.Sign() < 0 {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidBoundary, text)
+	}
+	return value, nil
+}
+
+// bigFamily is family with big bounds.
+type bigFamily struct {
+	next   *big.Int
+	last   *big.Int
+	factor *big.Int
+	chunk  int
+}
+
+// bigDigitCount returns the number of base-radix digits of n.
+func bigDigitCount(n *big.Int, radix int) int {
+	if n.Sign() == 0 {
+		return 1
+	}
+	return len(n.Text(radix))
+}
+
+// bigChunkFamily is chunkFamily with big bounds.
+func bigChunkFamily(start, end *big.Int, radix, chunkDigits, repeat int) (bigFamily, bool) {
+	r := big.NewInt(int64(radix))
+	base := new(big.Int).Exp(r, big.NewInt(int64(chunkDigits)), nil)
+
+	// factor = (base^repeat - 1) / (base - 1)
+	factor := new(big.Int).Exp(base, big.NewInt(int64(repeat)), nil)
+	factor.Sub(factor, big.NewInt(1))
+	factor.Quo(factor, new(big.Int).Sub(base, big.NewInt(1)))
+
+	chunkMin := new(big.Int).Exp(r, big.NewInt(int64(chunkDigits-1)), nil)
+	chunkMax := new(big.Int).Sub(base, big.NewInt(1))
+
+	// ceil(start / factor)
+	startChunk, rem := new(big.Int).QuoRem(start, factor, new(big.Int))
+	if rem.Sign() != 0 {
+		startChunk.Add(startChunk, big.NewInt(1))
+	}
+	if startChunk.Cmp(chunkMin) > 0 {
+		chunkMin = startChunk
+	}
+	endChunk := new(big.Int).Quo(end, factor)
+	if endChunk.Cmp(chunkMax) < 0 {
+		chunkMax = endChunk
+	}
+	if chunkMin.Cmp(chunkMax) > 0 {
+		return bigFamily{}, false
+	}
+	return bigFamily{
+		next:   chunkMin.Mul(chunkMin, factor),
+		last:   chunkMax.Mul(chunkMax, factor),
+		factor: factor,
+		chunk:  chunkDigits,
+	}, true
+}
+
+// bigFamilies is families with big bounds.
+func bigFamilies(start, end *big.Int, digits int, rule Rule) []bigFamily {
+	var res []bigFamily
+	for chunkDigits := 1; chunkDigits <= digits/2; chunkDigits++ {
+		if digits%chunkDigits != 0 {
+			continue
+		}
+
+		repeat := digits / chunkDigits
+		if repeat < 2 || !rule.accepts(repeat) {
+			continue
+		}
+
+		f, ok := bigChunkFamily(start, end, rule.base(), chunkDigits, repeat)
+		if ok {
+			res = append(res, f)
+		}
+	}
+	return res
+}
+
+// invalidInBigRange is invalidInRange with big bounds.
+func invalidInBigRange(start, end *big.Int, rule Rule) iter.Seq[*big.Int] {
+	return func(yield func(*big.Int) bool) {
+		if end.Cmp(start) < 0 {
+			return
+		}
+
+		minDigits := bigDigitCount(start, rule.base())
+		if minDigits < 2 {
+			minDigits = 2
+		}
+		maxDigits := bigDigitCount(end, rule.base())
+
+		for digits := minDigits; digits <= maxDigits; digits++ {
+			fams := bigFamilies(start, end, digits, rule)
+			for len(fams) > 0 {
+				lowest := fams[0].next
+				for _, f := range fams[1:] {
+					if f.next.Cmp(lowest) < 0 {
+						lowest = f.next
+					}
+				}
+				lowest = new(big.Int).Set(lowest)
+				if !yield(new(big.Int).Set(lowest)) {
+					return
+				}
+
+				kept := fams[:0]
+				for _, f := range fams {
+					if f.next.Cmp(lowest) == 0 {
+						if f.next.Cmp(f.last) == 0 {
+							continue
+						}
+						f.next.Add(f.next, f.factor)
+					}
+					kept = append(kept, f)
+				}
+				fams = kept
+			}
+		}
+	}
+}
+
+// totalsInBigRange is totalsInRange with big bounds.
+func totalsInBigRange(start, end *big.Int, rule Rule) (*big.Int, *big.Int) {
+	count := new(big.Int)
+	sum := new(big.Int)
+	if end.Cmp(start) < 0 {
+		return count, sum
+	}
+
+	minDigits := bigDigitCount(start, rule.base())
+	if minDigits < 2 {
+		minDigits = 2
+	}
+	maxDigits := bigDigitCount(end, rule.base())
+
+	for digits := minDigits; digits <= maxDigits; digits++ {
+		var chunks []int
+		for _, f := range bigFamilies(start, end, digits, rule) {
+			chunks = append(chunks, f.chunk)
+		}
+
+		for g, k := range blockCoefficients(chunks) {
+			f, ok := bigChunkFamily(start, end, rule.base(), g, digits/g)
+			if !ok {
+				continue
+			}
+			coef := big.NewInt(k)
+
+			// n = (last - first) / factor + 1
+			n := new(big.Int).Sub(f.last, f.next)
+			n.Quo(n, f.factor)
+			n.Add(n, big.NewInt(1))
+			count.Add(count, new(big.Int).Mul(n, coef))
+
+			// Sum of the progression: n * (first + last) / 2.
+			term := new(big.Int).Add(f.next, f.last)
+			term.Mul(term, n)
+			term.Rsh(term, 1)
+			term.Mul(term, coef)
+			sum.Add(sum, term)
+		}
+	}
+	return count, sum
+}
@@ -4,7 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"sort"
+	"iter"
+	"math"
+	"math/big"
 	"strconv"
 	"strings"
 )
@@ -13,48 +15,175 @@ var (
 	ErrNoRanges        = errors.New("no ranges")
 	ErrInvalidRange    = errors.New("invalid range")
 	ErrInvalidBoundary = errors.New("invalid range boundary")
+	ErrInvalidMode     = errors.New("invalid mode")
+	ErrSumOverflow     = errors.New("sum overflow")
 )
 
 type Result struct {
 	InvalidIDs []int64
-	Sum        int64
+	// BigInvalidIDs lists, in ascending order, the invalid IDs too large for
+	// InvalidIDs. They all come after InvalidIDs.
+	BigInvalidIDs []*big.Int
+	Sum           int64
+	// BigSum holds the exact sum when it does not fit in int64, in which case
+	// Sum is math.MaxInt64.
+	BigSum *big.Int
+}
+
+// Mode selects which repetitions make an ID invalid.
+type Mode int
+
+const (
+	// RepeatAtLeastTwice matches a digit block repeated two or more times
+	// (11, 6464, 123123123). This is the part 2 rule and the default.
+	RepeatAtLeastTwice Mode = iota
+	// RepeatExactlyTwice matches a digit block repeated exactly twice (6464 but
+	// not 646464). This is the part 1 rule.
+	RepeatExactlyTwice
+)
+
+// Options configures ComputeWith.
+type Options struct {
+	Mode Mode
+	// Rule, when its Count is set, replaces Mode with a custom repetition rule.
+	Rule Rule
+}
+
+// rule resolves the repetition rule selected by the options.
+func (o Options) rule() (Rule, error) {
+	if o.Rule.Count != 0 {
+		if err := o.Rule.validate(); err != nil {
+			return Rule{}, err
+		}
+		return o.Rule, nil
+	}
+	switch o.Mode {
+	case RepeatAtLeastTwice:
+		return Rule{Kind: AtLeast, Count: 2}, nil
+	case RepeatExactlyTwice:
+		return Rule{Kind: Exactly, Count: 2}, nil
+	default:
+		return Rule{}, fmt.Errorf("%w: %d", ErrInvalidMode, o.Mode)
+	}
 }
 
 // Compute parses comma-separated ranges from the reader, identifies the invalid IDs,
 // and returns them alongside their sum. Invalid IDs consist of any digit sequence
 // repeated at least twice (e.g. 11, 6464, 123123, 123123123) without leading zeroes.
 func Compute(r io.Reader) (Result, error) {
-	payload, err := io.ReadAll(r)
+	return ComputeWith(r, Options{})
+}
+
+// ComputeWith is Compute with the repetition rule selected by opts. Range
+// bounds are read in the rule's base and may exceed int64; inputs that fit in
+// int64 take the int64 path.
+func ComputeWith(r io.Reader, opts Options) (Result, error) {
+	rule, ranges, err := loadBigRanges(r, opts)
 	if err != nil {
 		return Result{}, err
 	}
 
-	ranges, err := parseRanges(string(payload))
-	if err != nil {
-		return Result{}, err
+	var res Result
+	var bigSum *big.Int
+	add := func(id *big.Int) {
+		if bigSum == nil {
+			bigSum = big.NewInt(res.Sum)
+		}
+		bigSum.Add(bigSum, id)
+	}
+
+	if small, ok := fitInt64(ranges); ok {
+		for _, rg := range small {
+			for id := range invalidInRange(rg.Start, rg.End, rule) {
+				res.InvalidIDs = append(res.InvalidIDs, id)
+				switch {
+				case bigSum != nil:
+					add(big.NewInt(id))
+				case res.Sum > math.MaxInt64-id:
+					add(big.NewInt(id))
+				default:
+					res.Sum += id
+				}
+			}
+		}
+	} else {
+		for _, rg := range ranges {
+			for id := range invalidInBigRange(rg.Start, rg.End, rule) {
+				if id.IsInt64() {
+					res.InvalidIDs = append(res.InvalidIDs, id.Int64())
+				} else {
+					res.BigInvalidIDs = append(res.BigInvalidIDs, id)
+				}
+				if bigSum == nil && id.IsInt64() && res.Sum <= math.MaxInt64-id.Int64() {
+					res.Sum += id.Int64()
+					continue
+				}
+				add(id)
+			}
+		}
 	}
-	if len(ranges) == 0 {
-		return Result{}, ErrNoRanges
+
+	if bigSum != nil {
+		res.Sum = math.MaxInt64
+		res.BigSum = bigSum
 	}
+	return res, nil
+}
 
-	seen := make(map[int64]struct{})
-	var invalidIDs []int64
-	var total int64
+// ComputeStream parses the ranges like ComputeWith but returns the invalid IDs
+// lazily, in ascending order and without duplicates. Overlapping ranges are
+// merged up front, so memory does not grow with the number of IDs. Bounds must
+// fit in int64; ComputeBigStream lifts that limit.
+func ComputeStream(r io.Reader, opts Options) (iter.Seq[int64], error) {
+	rule, ranges, err := loadRanges(r, opts)
+	if err != nil {
+		return nil, err
+	}
 
-	for _, rg := range ranges {
-		ids := invalidInRange(rg.Start, rg.End)
-		for _, id := range ids {
-			if _, ok := seen[id]; ok {
-				continue
+	return func(yield func(int64) bool) {
+		for _, rg := range ranges {
+			for id := range invalidInRange(rg.Start, rg.End, rule) {
+				if !yield(id) {
+					return
+				}
 			}
-			seen[id] = struct{}{}
-			invalidIDs = append(invalidIDs, id)
-			total += id
 		}
+	}, nil
+}
+
+// Totals holds the number of distinct invalid IDs and their sum.
+type Totals struct {
+	Count int64
+	Sum   int64
+}
+
+// ComputeTotals returns the count and sum of the distinct invalid IDs without
+// enumerating them, in time independent of the size of the ranges. It fails
+// with ErrSumOverflow when a total does not fit in int64; ComputeBigTotals
+// returns exact totals.
+func ComputeTotals(r io.Reader, opts Options) (Totals, error) {
+	totals, err := ComputeBigTotals(r, opts)
+	if err != nil {
+		return Totals{}, err
+	}
+	if !totals.Count.IsInt64() || !totals.Sum.IsInt64() {
+		return Totals{}, fmt.Errorf("%w: %s", ErrSumOverflow, totals.Sum)
 	}
+	return Totals{Count: totals.Count.Int64(), Sum: totals.Sum.Int64()}, nil
+}
 
-	sort.Slice(invalidIDs, func(i, j int) bool { return invalidIDs[i] < invalidIDs[j] })
-	return Result{InvalidIDs: invalidIDs, Sum: total}, nil
+// loadRanges reads the input and returns the rule together with the ranges,
+// sorted and merged so that they are disjoint. Bounds must fit in int64.
+func loadRanges(r io.Reader, opts Options) (Rule, []valueRange, error) {
+	rule, ranges, err := loadBigRanges(r, opts)
+	if err != nil {
+		return Rule{}, nil, err
+	}
+	small, ok := fitInt64(ranges)
+	if !ok {
+		return Rule{}, nil, fmt.Errorf("%w: bound exceeds int64", ErrInvalidBoundary)
+	}
+	return rule, small, nil
 }
 
 type valueRange struct {
@@ -62,9 +191,9 @@ type valueRange struct {
 	End   int64
 }
 
-func parseRanges(input string) ([]valueRange, error) {
+func parseRanges(input string, base int) ([]bigRange, error) {
 	chunks := strings.Split(input, ",")
-	res := make([]valueRange, 0, len(chunks))
+	res := make([]bigRange, 0, len(chunks))
 
 	for idx, raw := range chunks {
 		token := strings.TrimSpace(raw)
@@ -77,27 +206,32 @@ func parseRanges(input string) ([]valueRange, error) {
 			return nil, fmt.Errorf("%w: %s", ErrInvalidRange, token)
 		}
 
-		minVal, err := parseBoundary(parts[0])
+		minVal, err := parseBoundary(parts[0], base)
 		if err != nil {
 			return nil, fmt.Errorf("range %d (%s): %w", idx+1, token, err)
 		}
-		maxVal, err := parseBoundary(parts[1])
+		maxVal, err := parseBoundary(parts[1], base)
 		if err != nil {
 			return nil, fmt.Errorf("range %d (%s): %w", idx+1, token, err)
 		}
-		if minVal > maxVal {
-			return nil, fmt.Errorf("%w: start %d greater than end %d", ErrInvalidRange, minVal, maxVal)
+		if minVal.Cmp(maxVal) > 0 {
+			return nil, fmt.Errorf("%w: start %s greater than end %s", ErrInvalidRange, minVal, maxVal)
 		}
-		res = append(res, valueRange{Start: minVal, End: maxVal})
+		res = append(res, bigRange{Start: minVal, End: maxVal})
 	}
 
 	return res, nil
 }
 
-func parseBoundary(part string) (int64, error) {
-	value, err := strconv.ParseInt(strings.TrimSpace(part), 10, 64)
-	if err != nil {
-		return 0, fmt.Errorf("%w: %v", ErrInvalidBoundary, err)
+// parseBoundary reads a bound with strconv when it fits in int64 and falls
+// back to big.Int otherwise.
+func parseBoundary(part string, base int) (*big.Int, error) {
+	value, err := strconv.ParseInt(strings.TrimSpace(part), base, 64)
+	if err == nil {
+		return big.NewInt(value), nil
+	}
+	if !errors.Is(err, strconv.ErrRange) {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidBoundary, err)
 	}
-	return value, nil
+	return parseBigBoundary(part, base)
 }
@@ -1,77 +1,226 @@
 package day2
 
 import (
+	"iter"
 	"math"
+	"math/big"
+	"sort"
 )
 
-func invalidInRange(start, end int64) []int64 {
+// family is the arithmetic progression chunk*factor of IDs made of one chunk
+// of c digits repeated digits/c times, clipped to a range.
+type family struct {
+	next   int64
+	last   int64
+	factor int64
+	chunk  int
+}
+
+// families returns one progression per block size accepted by rule for IDs of
+// the given digit count in [start, end]. IDs are built in closed form as
+// chunk * factor, where factor = 1 + B^c + B^2c + ... for a chunk of c digits
+// in base B.
+func families(start, end int64, digits int, rule Rule) []family {
+	radix := int64(rule.base())
+	var res []family
+	for chunkDigits := 1; chunkDigits <= digits/2; chunkDigits++ {
+		if digits%chunkDigits != 0 {
+			continue
+		}
+
+		repeat := digits / chunkDigits
+		if repeat < 2 || !rule.accepts(repeat) {
+			continue
+		}
+
+		f, ok := chunkFamily(start, end, radix, chunkDigits, repeat)
+		if ok {
+			res = append(res, f)
+		}
+	}
+	return res
+}
+
+// chunkFamily returns the IDs in [start, end] made of a chunk of chunkDigits
+// digits repeated repeat times.
+func chunkFamily(start, end, radix int64, chunkDigits, repeat int) (family, bool) {
+	base := pow(radix, chunkDigits)
+	if base == 0 {
+		return family{}, false
+	}
+	factor := repeatFactor(base, repeat)
+	if factor == 0 {
+		return family{}, false
+	}
+
+	chunkMin := pow(radix, chunkDigits-1)
+	chunkMax := base - 1
+
+	startChunk := ceilDiv(start, factor)
+	if startChunk > chunkMin {
+		chunkMin = startChunk
+	}
+	endChunk := end / factor
+	if endChunk < chunkMax {
+		chunkMax = endChunk
+	}
+	if chunkMin > chunkMax {
+		return family{}, false
+	}
+	return family{next: chunkMin * factor, last: chunkMax * factor, factor: factor, chunk: chunkDigits}, true
+}
+
+// digitBounds returns the digit counts of invalid IDs worth scanning in
+// [start, end].
+func digitBounds(start, end int64, rule Rule) (int, int, bool) {
 	if end < start || end < 0 {
-		return nil
+		return 0, 0, false
 	}
 	if start < 0 {
 		start = 0
 	}
 
-	minDigits := digitCount(start)
+	radix := int64(rule.base())
+	minDigits := digitCount(start, radix)
 	if minDigits < 2 {
 		minDigits = 2
 	}
-	maxDigits := digitCount(end)
+	return minDigits, digitCount(end, radix), true
+}
 
-	var result []int64
-	for digits := minDigits; digits <= maxDigits; digits++ {
-		maxChunkDigits := digits / 2
-		if maxChunkDigits == 0 {
-			continue
+// invalidInRange yields the IDs in [start, end] matching rule in ascending
+// order, each once. IDs of a given digit count come from a few progressions
+// (one per block size) which are merged on the fly.
+func invalidInRange(start, end int64, rule Rule) iter.Seq[int64] {
+	return func(yield func(int64) bool) {
+		minDigits, maxDigits, ok := digitBounds(start, end, rule)
+		if !ok {
+			return
+		}
+		if start < 0 {
+			start = 0
 		}
 
-		for chunkDigits := 1; chunkDigits <= maxChunkDigits; chunkDigits++ {
-			if digits%chunkDigits != 0 {
-				continue
-			}
+		for digits := minDigits; digits <= maxDigits; digits++ {
+			fams := families(start, end, digits, rule)
+			for len(fams) > 0 {
+				lowest := fams[0].next
+				for _, f := range fams[1:] {
+					if f.next < lowest {
+						lowest = f.next
+					}
+				}
+				if !yield(lowest) {
+					return
+				}
 
-			repeat := digits / chunkDigits
-			if repeat < 2 {
-				continue
+				// Advance every progression sitting on the emitted ID, dropping
+				// the exhausted ones.
+				kept := fams[:0]
+				for _, f := range fams {
+					if f.next == lowest {
+						if f.next == f.last {
+							continue
+						}
+						f.next += f.factor
+					}
+					kept = append(kept, f)
+				}
+				fams = kept
 			}
+		}
+	}
+}
 
-			base := pow10(chunkDigits)
-			if base == 0 {
-				continue
-			}
-			factor := repeatFactor(base, repeat)
-			if factor == 0 {
+// totalsInRange counts and sums the IDs in [start, end] matching rule without
+// enumerating them. For a digit count d, the IDs made of a block of c digits
+// form a set S_c, and S_a ∩ S_b = S_gcd(a,b), so the union over accepted block
+// sizes follows from inclusion-exclusion over subsets of block sizes.
+func totalsInRange(start, end int64, rule Rule) (int64, *big.Int) {
+	sum := new(big.Int)
+	var count int64
+
+	minDigits, maxDigits, ok := digitBounds(start, end, rule)
+	if !ok {
+		return 0, sum
+	}
+	if start < 0 {
+		start = 0
+	}
+
+	radix := int64(rule.base())
+	for digits := minDigits; digits <= maxDigits; digits++ {
+		var chunks []int
+		for _, f := range families(start, end, digits, rule) {
+			chunks = append(chunks, f.chunk)
+		}
+
+		for g, k := range blockCoefficients(chunks) {
+			f, ok := chunkFamily(start, end, radix, g, digits/g)
+			if !ok {
 				continue
 			}
+			n := (f.last-f.next)/f.factor + 1
+			count += k * n
 
-			chunkMin := pow10(chunkDigits - 1)
-			chunkMax := base - 1
+			// Sum of the progression: n * (first + last) / 2.
+			term := new(big.Int).Add(big.NewInt(f.next), big.NewInt(f.last))
+			term.Mul(term, big.NewInt(n))
+			term.Rsh(term, 1)
+			term.Mul(term, big.NewInt(k))
+			sum.Add(sum, term)
+		}
+	}
+	return count, sum
+}
 
-			startChunk := ceilDiv(start, factor)
-			if startChunk > chunkMin {
-				chunkMin = startChunk
-			}
-			endChunk := end / factor
-			if endChunk < chunkMax {
-				chunkMax = endChunk
-			}
-			if chunkMin > chunkMax {
+// blockCoefficients returns the coefficients k_g such that the union of S_c
+// over the given block sizes is the sum of k_g·S_g. Since S_a ∩ S_b = S_gcd(a,b),
+// only divisors of the block sizes can appear. Walking them from the largest
+// down, each one gets 1 minus the coefficients of its multiples, which keeps
+// the work quadratic in the number of divisors instead of exponential in the
+// number of block sizes. Zero coefficients are left out.
+func blockCoefficients(chunks []int) map[int]int64 {
+	seen := make(map[int]bool)
+	var divisors []int
+	for _, c := range chunks {
+		for d := 1; d*d <= c; d++ {
+			if c%d != 0 {
 				continue
 			}
-
-			for chunk := chunkMin; chunk <= chunkMax; chunk++ {
-				candidate := chunk * factor
-				if candidate >= start && candidate <= end {
-					result = append(result, candidate)
+			for _, v := range []int{d, c / d} {
+				if !seen[v] {
+					seen[v] = true
+					divisors = append(divisors, v)
 				}
 			}
 		}
 	}
+	sort.Sort(sort.Reverse(sort.IntSlice(divisors)))
 
-	return result
+	coef := make(map[int]int64)
+	for i, g := range divisors {
+		k := int64(1)
+		for _, h := range divisors[:i] {
+			if h%g == 0 {
+				k -= coef[h]
+			}
+		}
+		if k != 0 {
+			coef[g] = k
+		}
+	}
+	return coef
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
 }
 
-func digitCount(n int64) int {
+func digitCount(n int64, radix int64) int {
 	if n < 0 {
 		n = -n
 	}
@@ -81,21 +230,22 @@ func digitCount(n int64) int {
 	count := 0
 	for n > 0 {
 		count++
-		n /= 10
+		n /= radix
 	}
 	return count
 }
 
-func pow10(exp int) int64 {
+// pow returns radix^exp, or 0 when it overflows int64.
+func pow(radix int64, exp int) int64 {
 	if exp <= 0 {
 		return 1
 	}
 	result := int64(1)
 	for i := 0; i < exp; i++ {
-		if result > math.MaxInt64/10 {
+		if result > math.MaxInt64/radix {
 			return 0
 		}
-		result *= 10
+		result *= radix
 	}
 	return result
 }
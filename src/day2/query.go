@@ -0,0 +1,100 @@
+package day2
+
+import (
+	"errors"
+	"math"
+)
+
+var ErrNoInvalidID = errors.New("no invalid ID")
+
+// queryRule resolves the rule used by point queries: a zero Rule stands for
+// the default part 2 rule, like a zero Options does for ComputeWith.
+func queryRule(rule Rule) (Rule, error) {
+	return Options{Rule: rule}.rule()
+}
+
+// IsInvalid reports whether id matches rule.
+func IsInvalid(id int64, rule Rule) (bool, error) {
+	rule, err := queryRule(rule)
+	if err != nil {
+		return false, err
+	}
+	if id < 0 {
+		return false, nil
+	}
+	return len(families(id, id, digitCount(id, int64(rule.base())), rule)) > 0, nil
+}
+
+// NextInvalid returns the smallest invalid ID strictly greater than n, or
+// ErrNoInvalidID when there is none in int64.
+func NextInvalid(n int64, rule Rule) (int64, error) {
+	rule, err := queryRule(rule)
+	if err != nil {
+		return 0, err
+	}
+	if n == math.MaxInt64 {
+		return 0, ErrNoInvalidID
+	}
+	start := n + 1
+	if start < 0 {
+		start = 0
+	}
+
+	radix := int64(rule.base())
+	maxDigits := digitCount(math.MaxInt64, radix)
+	for digits := max(digitCount(start, radix), 2); digits <= maxDigits; digits++ {
+		end := int64(math.MaxInt64)
+		if limit := pow(radix, digits); limit != 0 {
+			end = limit - 1
+		}
+
+		fams := families(start, end, digits, rule)
+		if len(fams) == 0 {
+			continue
+		}
+		best := fams[0].next
+		for _, f := range fams[1:] {
+			best = min(best, f.next)
+		}
+		return best, nil
+	}
+	return 0, ErrNoInvalidID
+}
+
+// PrevInvalid returns the largest invalid ID strictly less than n, or
+// ErrNoInvalidID when there is none.
+func PrevInvalid(n int64, rule Rule) (int64, error) {
+	rule, err := queryRule(rule)
+	if err != nil {
+		return 0, err
+	}
+	if n <= 0 {
+		return 0, ErrNoInvalidID
+	}
+	end := n - 1
+
+	radix := int64(rule.base())
+	for digits := digitCount(end, radix); digits >= 2; digits-- {
+		fams := families(pow(radix, digits-1), end, digits, rule)
+		if len(fams) == 0 {
+			continue
+		}
+		best := fams[0].last
+		for _, f := range fams[1:] {
+			best = max(best, f.last)
+		}
+		return best, nil
+	}
+	return 0, ErrNoInvalidID
+}
+
+// Rank returns how many invalid IDs are less than or equal to n, which lets
+// callers paginate through invalid IDs without enumerating them.
+func Rank(n int64, rule Rule) (int64, error) {
+	rule, err := queryRule(rule)
+	if err != nil {
+		return 0, err
+	}
+	count, _ := totalsInRange(0, n, rule)
+	return count, nil
+}
@@ -0,0 +1,153 @@
+package day2
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// SharedIDLimit is the largest number of shared invalid IDs listed for one
+// range; Shared still counts all of them.
+const SharedIDLimit = 100
+
+// RangeReport describes the invalid IDs of one input range.
+type RangeReport struct {
+	Start *big.Int `json:"start"`
+	End   *big.Int `json:"end"`
+	Count *big.Int `json:"count"`
+	Sum   *big.Int `json:"sum"`
+	// Shared counts the invalid IDs of the range that also belong to at least
+	// one other input range.
+	Shared *big.Int `json:"shared"`
+	// Overlaps lists, in ascending order, the disjoint stretches of the range
+	// covered by other input ranges.
+	Overlaps []Overlap `json:"overlaps"`
+}
+
+// Overlap is a stretch of a range shared with other ranges, together with the
+// number of invalid IDs it holds. IDs lists them in ascending order, up to
+// SharedIDLimit per range across its overlaps.
+type Overlap struct {
+	Start *big.Int   `json:"start"`
+	End   *big.Int   `json:"end"`
+	Count *big.Int   `json:"count"`
+	IDs   []*big.Int `json:"ids"`
+}
+
+// Report breaks the invalid IDs down by input range. Count and Sum cover the
+// distinct IDs, so an ID shared by several ranges is counted once there but
+// once per range in Ranges.
+type Report struct {
+	Ranges []RangeReport `json:"ranges"`
+	Count  *big.Int      `json:"count"`
+	Sum    *big.Int      `json:"sum"`
+}
+
+// ComputeReport reads the ranges like ComputeWith and reports the invalid IDs
+// of every range, in input order. Totals are computed without enumerating the
+// IDs, overlaps included; only the listed shared IDs are generated, lazily.
+func ComputeReport(r io.Reader, opts Options) (Report, error) {
+	rule, ranges, err := readBigRanges(r, opts)
+	if err != nil {
+		return Report{}, err
+	}
+
+	shared := sharedStretches(ranges)
+	rep := Report{Ranges: make([]RangeReport, len(ranges)), Count: new(big.Int), Sum: new(big.Int)}
+	for i, rg := range ranges {
+		count, sum := totalsInBigRange(rg.Start, rg.End, rule)
+		rr := RangeReport{Start: rg.Start, End: rg.End, Count: count, Sum: sum, Shared: new(big.Int), Overlaps: []Overlap{}}
+
+		listed := 0
+		first := sort.Search(len(shared), func(k int) bool { return shared[k].End.Cmp(rg.Start) >= 0 })
+		for _, st := range shared[first:] {
+			if st.Start.Cmp(rg.End) > 0 {
+				break
+			}
+			o := Overlap{Start: bigMax(st.Start, rg.Start), End: bigMin(st.End, rg.End), IDs: []*big.Int{}}
+			o.Count, _ = totalsInBigRange(o.Start, o.End, rule)
+			for id := range invalidInBigRange(o.Start, o.End, rule) {
+				if listed == SharedIDLimit {
+					break
+				}
+				o.IDs = append(o.IDs, id)
+				listed++
+			}
+			rr.Shared.Add(rr.Shared, o.Count)
+			rr.Overlaps = append(rr.Overlaps, o)
+		}
+		rep.Ranges[i] = rr
+	}
+
+	// mergeBigRanges reorders its argument, so merge a copy.
+	merged := mergeBigRanges(append([]bigRange(nil), ranges...))
+	for _, rg := range merged {
+		count, sum := totalsInBigRange(rg.Start, rg.End, rule)
+		rep.Count.Add(rep.Count, count)
+		rep.Sum.Add(rep.Sum, sum)
+	}
+	return rep, nil
+}
+
+// sharedStretches returns, in ascending order, the disjoint stretches covered
+// by at least two of the ranges. Swept by start, a range overlaps the ones
+// before it exactly up to the furthest end seen so far.
+func sharedStretches(ranges []bigRange) []bigRange {
+	sorted := append([]bigRange(nil), ranges...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Start.Cmp(sorted[j].Start) < 0 })
+
+	var shared []bigRange
+	reach := sorted[0].End
+	for _, rg := range sorted[1:] {
+		if rg.Start.Cmp(reach) <= 0 {
+			shared = append(shared, bigRange{Start: rg.Start, End: bigMin(rg.End, reach)})
+		}
+		reach = bigMax(reach, rg.End)
+	}
+	if len(shared) == 0 {
+		return nil
+	}
+	return mergeBigRanges(shared)
+}
+
+// WriteTable prints the report as an aligned table with one row per range and
+// a final row for the distinct totals.
+func (rep Report) WriteTable(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "RANGE\tCOUNT\tSUM\tSHARED")
+	for _, rr := range rep.Ranges {
+		var ids []string
+		for _, o := range rr.Overlaps {
+			for _, id := range o.IDs {
+				ids = append(ids, id.String())
+			}
+		}
+		shared := "-"
+		if len(ids) > 0 {
+			shared = strings.Join(ids, ",")
+		}
+		if rr.Shared.Cmp(big.NewInt(int64(len(ids)))) > 0 {
+			shared = fmt.Sprintf("%s,... (%s in all)", shared, rr.Shared)
+		}
+		fmt.Fprintf(tw, "%s-%s\t%s\t%s\t%s\n", rr.Start, rr.End, rr.Count, rr.Sum, shared)
+	}
+	fmt.Fprintf(tw, "TOTAL\t%s\t%s\n", rep.Count, rep.Sum)
+	return tw.Flush()
+}
+
+func bigMax(a, b *big.Int) *big.Int {
+	if a.Cmp(b) >= 0 {
+		return a
+	}
+	return b
+}
+
+func bigMin(a, b *big.Int) *big.Int {
+	if a.Cmp(b) <= 0 {
+		return a
+	}
+	return b
+}
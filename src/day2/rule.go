@@ -0,0 +1,122 @@
+package day2
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidRule = errors.New("invalid rule")
+
+// RuleKind tells how a Rule compares the repeat count of a block with Count.
+type RuleKind int
+
+const (
+	// AtLeast matches blocks repeated Count or more times.
+	AtLeast RuleKind = iota
+	// Exactly matches blocks repeated exactly Count times.
+	Exactly
+	// AtMost matches blocks repeated between 2 and Count times.
+	AtMost
+)
+
+var ruleKindNames = map[RuleKind]string{
+	AtLeast: "atleast",
+	Exactly: "exactly",
+	AtMost:  "atmost",
+}
+
+// Rule describes which repeated-block identifiers are invalid: IDs written in
+// radix Base as a single block of digits (no leading zero) repeated a number of
+// times accepted by Kind and Count. A block always repeats at least twice.
+type Rule struct {
+	Kind  RuleKind
+	Count int
+	// Base is the radix IDs and range bounds are written in; 0 means 10.
+	Base int
+}
+
+// ParseRule parses a rule spec of the form `<kind>:<count>[/<base>]`, where kind
+// is one of atleast, exactly or atmost, e.g. "exactly:2" or "atleast:3/16".
+func ParseRule(spec string) (Rule, error) {
+	kindText, rest, ok := strings.Cut(strings.TrimSpace(spec), ":")
+	if !ok {
+		return Rule{}, fmt.Errorf("%w: %q", ErrInvalidRule, spec)
+	}
+
+	var rule Rule
+	found := false
+	for kind, name := range ruleKindNames {
+		if name == kindText {
+			rule.Kind = kind
+			found = true
+		}
+	}
+	if !found {
+		return Rule{}, fmt.Errorf("%w: unknown kind %q", ErrInvalidRule, kindText)
+	}
+
+	countText, baseText, hasBase := strings.Cut(rest, "/")
+	count, err := strconv.Atoi(countText)
+	if err != nil {
+		return Rule{}, fmt.Errorf("%w: count %q", ErrInvalidRule, countText)
+	}
+	rule.Count = count
+	if hasBase {
+		base, err := strconv.Atoi(baseText)
+		if err != nil {
+			return Rule{}, fmt.Errorf("%w: base %q", ErrInvalidRule, baseText)
+		}
+		rule.Base = base
+	}
+
+	if err := rule.validate(); err != nil {
+		return Rule{}, err
+	}
+	return rule, nil
+}
+
+// String renders the rule in the ParseRule format.
+func (r Rule) String() string {
+	return fmt.Sprintf("%s:%d/%d", ruleKindNames[r.Kind], r.Count, r.base())
+}
+
+func (r Rule) base() int {
+	if r.Base == 0 {
+		return 10
+	}
+	return r.Base
+}
+
+func (r Rule) validate() error {
+	if _, ok := ruleKindNames[r.Kind]; !ok {
+		return fmt.Errorf("%w: kind %d", ErrInvalidRule, r.Kind)
+	}
+	if r.Count < 2 {
+		return fmt.Errorf("%w: count %d below 2", ErrInvalidRule, r.Count)
+	}
+	if b := r.base(); b < 2 || b > 36 {
+		return fmt.Errorf("%w: base %d outside 2..36", ErrInvalidRule, b)
+	}
+	return nil
+}
+
+// repeatBounds returns the accepted repeat counts as [min, max], max 0 meaning
+// no upper bound.
+func (r Rule) repeatBounds() (int, int) {
+	switch r.Kind {
+	case Exactly:
+		return r.Count, r.Count
+	case AtMost:
+		return 2, r.Count
+	default:
+		return r.Count, 0
+	}
+}
+
+// accepts reports whether a block repeated repeat times matches the rule.
+func (r Rule) accepts(repeat int) bool {
+	minRepeat, maxRepeat := r.repeatBounds()
+	return repeat >= minRepeat && (maxRepeat == 0 || repeat <= maxRepeat)
+}
@@ -0,0 +1,66 @@
+package day2
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"adventofcode2025/day1/src/aoc"
+)
+
+// solver adds a per-range report to the registered parts.
+type solver struct {
+	aoc.Solver
+}
+
+// partOptions holds the options selecting the rule of each part.
+var partOptions = map[int]Options{
+	1: {Mode: RepeatExactlyTwice},
+	2: {},
+}
+
+func init() {
+	aoc.Register(2, solver{aoc.Parts{1: solvePart1, 2: solvePart2}})
+}
+
+func solvePart1(r io.Reader) (string, error) {
+	totals, err := ComputeBigTotals(r, partOptions[1])
+	if err != nil {
+		return "", err
+	}
+	return totals.Sum.String(), nil
+}
+
+func solvePart2(r io.Reader) (string, error) {
+	totals, err := ComputeBigTotals(r, partOptions[2])
+	if err != nil {
+		return "", err
+	}
+	return totals.Sum.String(), nil
+}
+
+// Report writes the per-range breakdown of part as a "table" (the default) or
+// as "json".
+func (solver) Report(part int, r io.Reader, w io.Writer, format string) error {
+	opts, ok := partOptions[part]
+	if !ok {
+		return fmt.Errorf("%w: %d", aoc.ErrUnknownPart, part)
+	}
+	if format == "" {
+		format = "table"
+	}
+	if format != "table" && format != "json" {
+		return fmt.Errorf("%w: %q", aoc.ErrUnknownFormat, format)
+	}
+
+	rep, err := ComputeReport(r, opts)
+	if err != nil {
+		return err
+	}
+	if format == "table" {
+		return rep.WriteTable(w)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(rep)
+}
@@ -5,86 +5,205 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
+	"math/big"
 	"strings"
 )
 
 var (
-	ErrNoBanks      = errors.New("no banks provided")
-	ErrInvalidDigit = errors.New("invalid digit in bank")
-	ErrBankTooShort = errors.New("bank must contain at least 12 batteries")
+	ErrNoBanks        = errors.New("no banks provided")
+	ErrInvalidDigit   = errors.New("invalid digit in bank")
+	ErrBankTooShort   = errors.New("bank has fewer batteries than required")
+	ErrInvalidOptions = errors.New("invalid options")
 )
 
-const requiredBatteries = 12
+// DefaultBatteries is the number of batteries turned on per bank in part 2.
+const DefaultBatteries = 12
+
+// maxInt64Digits is the longest joltage guaranteed to fit in an int64.
+const maxInt64Digits = 18
 
 type Result struct {
 	Banks int
 	Total int64
+	// BigTotal holds the exact total when it does not fit in int64, in which
+	// case Total is math.MaxInt64.
+	BigTotal *big.Int
+}
+
+// Options configures ComputeWith.
+type Options struct {
+	// Batteries is the number of batteries turned on per bank. Zero means
+	// DefaultBatteries.
+	Batteries int
+}
+
+// batteries resolves the battery count selected by the options.
+func (o Options) batteries() (int, error) {
+	switch {
+	case o.Batteries == 0:
+		return DefaultBatteries, nil
+	case o.Batteries < 0:
+		return 0, fmt.Errorf("%w: %d batteries", ErrInvalidOptions, o.Batteries)
+	default:
+		return o.Batteries, nil
+	}
 }
 
 // Compute reads digit-only banks, picks the optimal set of 12 ordered batteries
 // per bank, and returns the total joltage sum.
 func Compute(r io.Reader) (Result, error) {
+	return ComputeWith(r, Options{})
+}
+
+// ComputeWith is Compute with the number of batteries per bank taken from
+// opts. Joltages of any length are supported; the total switches to BigTotal
+// once it no longer fits in int64.
+func ComputeWith(r io.Reader, opts Options) (Result, error) {
+	count, err := opts.batteries()
+	if err != nil {
+		return Result{}, err
+	}
+
+	var sum joltageSum
+	_, err = eachBank(r, func(_ int, bank string) error {
+		digits, err := maxBankJoltage(bank, count)
+		if err != nil {
+			return err
+		}
+		sum.add(digits)
+		return nil
+	})
+	if err != nil {
+		return Result{}, err
+	}
+	return sum.result(), nil
+}
+
+// joltageSum adds up bank joltages on int64 until the total overflows.
+type joltageSum struct {
+	banks int
+	total int64
+	big   *big.Int
+}
+
+// add counts one bank whose joltage has the given digits.
+func (s *joltageSum) add(digits []byte) {
+	s.banks++
+	if s.big == nil && len(digits) <= maxInt64Digits {
+		value := int64Value(digits)
+		if s.total <= math.MaxInt64-value {
+			s.total += value
+			return
+		}
+	}
+	if s.big == nil {
+		s.big = big.NewInt(s.total)
+	}
+	s.big.Add(s.big, bigValue(digits))
+}
+
+func (s joltageSum) result() Result {
+	if s.big != nil {
+		return Result{Banks: s.banks, Total: math.MaxInt64, BigTotal: s.big}
+	}
+	return Result{Banks: s.banks, Total: s.total}
+}
+
+// eachBank calls fn with every non-blank bank of r and its 1-based line
+// number, and returns the number of banks.
+func eachBank(r io.Reader, fn func(line int, bank string) error) (int, error) {
 	scanner := bufio.NewScanner(r)
 	scanner.Buffer(make([]byte, 0, 1024), 1024*1024)
 
-	var total int64
 	banks := 0
 	line := 0
-
 	for scanner.Scan() {
 		line++
 		raw := strings.TrimSpace(scanner.Text())
 		if raw == "" {
 			continue
 		}
-
-		value, err := maxBankJoltage(raw)
-		if err != nil {
-			return Result{}, fmt.Errorf("line %d: %w", line, err)
+		if err := fn(line, raw); err != nil {
+			return 0, fmt.Errorf("line %d: %w", line, err)
 		}
-		total += int64(value)
 		banks++
 	}
 
 	if err := scanner.Err(); err != nil {
-		return Result{}, err
+		return 0, err
 	}
 	if banks == 0 {
-		return Result{}, ErrNoBanks
+		return 0, ErrNoBanks
+	}
+	return banks, nil
+}
+
+// maxBankJoltage returns the digits of the largest joltage made of count
+// batteries of the bank, taken in order.
+func maxBankJoltage(bank string, count int) ([]byte, error) {
+	chosen, err := selectBatteries(bank, count)
+	if err != nil {
+		return nil, err
+	}
+	digits := make([]byte, len(chosen))
+	for i, idx := range chosen {
+		digits[i] = bank[idx] - '0'
 	}
+	return digits, nil
+}
 
-	return Result{Banks: banks, Total: total}, nil
+// selectBatteries returns, in ascending order, the indices of the count
+// batteries forming the largest joltage of the bank.
+func selectBatteries(bank string, count int) ([]int, error) {
+	return selectExtreme(bank, count, func(top, d byte) bool { return top < d })
 }
 
-func maxBankJoltage(bank string) (int64, error) {
+// selectExtreme is the monotonic stack behind selectBatteries: it keeps the
+// best prefix seen so far and pops its last digit while the incoming one
+// beats it and enough batteries are left to be dropped.
+func selectExtreme(bank string, count int, beats func(top, d byte) bool) ([]int, error) {
 	n := len(bank)
-	if n < requiredBatteries {
-		return 0, ErrBankTooShort
+	if n < count {
+		return nil, fmt.Errorf("%w: %d batteries, need %d", ErrBankTooShort, n, count)
 	}
 
-	toDrop := n - requiredBatteries
-	stack := make([]byte, 0, n)
+	toDrop := n - count
+	stack := make([]int, 0, n)
 
-	for _, ch := range bank {
+	for i, ch := range bank {
 		if ch < '0' || ch > '9' {
-			return 0, fmt.Errorf("%w: %q", ErrInvalidDigit, ch)
+			return nil, fmt.Errorf("%w: %q", ErrInvalidDigit, ch)
 		}
-		d := byte(ch - '0')
-		for toDrop > 0 && len(stack) > 0 && stack[len(stack)-1] < d {
+		for toDrop > 0 && len(stack) > 0 && beats(bank[stack[len(stack)-1]], bank[i]) {
 			stack = stack[:len(stack)-1]
 			toDrop--
 		}
-		stack = append(stack, d)
+		stack = append(stack, i)
 	}
 
 	// Trim to exact length if necessary.
-	if len(stack) > requiredBatteries {
-		stack = stack[:requiredBatteries]
+	if len(stack) > count {
+		stack = stack[:count]
 	}
+	return stack, nil
+}
 
+// int64Value converts at most maxInt64Digits digits to their value.
+func int64Value(digits []byte) int64 {
 	var value int64
-	for _, d := range stack {
+	for _, d := range digits {
 		value = value*10 + int64(d)
 	}
-	return value, nil
+	return value
+}
+
+// bigValue converts digits of any length to their value.
+func bigValue(digits []byte) *big.Int {
+	text := make([]byte, len(digits))
+	for i, d := range digits {
+		text[i] = '0' + d
+	}
+	value, _ := new(big.Int).SetString(string(text), 10)
+	return value
 }
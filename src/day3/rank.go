@@ -0,0 +1,124 @@
+package day3
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+)
+
+// Ranking holds the best and worst selections of one bank.
+type Ranking struct {
+	// Line is the 1-based input line of the bank.
+	Line int    `json:"line"`
+	Bank string `json:"bank"`
+	// Top lists selections with distinct joltages, highest first.
+	Top []Selection `json:"top"`
+	// Min is the selection giving the lowest joltage.
+	Min Selection `json:"min"`
+}
+
+// TopSelections returns up to k selections of batteries from the bank with
+// pairwise distinct joltages, highest first. Several index sets can give the
+// same joltage; each joltage is reported once, with its leftmost batteries.
+func TopSelections(bank string, batteries, k int) ([]Selection, error) {
+	count, err := Options{Batteries: batteries}.batteries()
+	if err != nil {
+		return nil, err
+	}
+	if k < 1 {
+		return nil, fmt.Errorf("%w: top %d", ErrInvalidOptions, k)
+	}
+	// selectBatteries validates the bank.
+	if _, err := selectBatteries(bank, count); err != nil {
+		return nil, err
+	}
+	return topSelections(bank, count, k), nil
+}
+
+// MinSelection returns the selection giving the lowest joltage of the bank
+// with the given number of batteries. Leading zeroes are kept in Joltage.
+func MinSelection(bank string, batteries int) (Selection, error) {
+	count, err := Options{Batteries: batteries}.batteries()
+	if err != nil {
+		return Selection{}, err
+	}
+	chosen, err := selectExtreme(bank, count, func(top, d byte) bool { return top > d })
+	if err != nil {
+		return Selection{}, err
+	}
+	return newSelection(bank, chosen), nil
+}
+
+// ComputeRanking reads the banks like ComputeWith and returns, for each of
+// them, the k best selections and the worst one.
+func ComputeRanking(r io.Reader, opts Options, k int) ([]Ranking, error) {
+	count, err := opts.batteries()
+	if err != nil {
+		return nil, err
+	}
+	if k < 1 {
+		return nil, fmt.Errorf("%w: top %d", ErrInvalidOptions, k)
+	}
+
+	var rankings []Ranking
+	_, err = eachBank(r, func(line int, bank string) error {
+		worst, err := MinSelection(bank, count)
+		if err != nil {
+			return err
+		}
+		top := topSelections(bank, count, k)
+		for i := range top {
+			top[i].Line = line
+		}
+		worst.Line = line
+		rankings = append(rankings, Ranking{Line: line, Bank: bank, Top: top, Min: worst})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rankings, nil
+}
+
+// topSelections enumerates the distinct joltages of a valid bank in
+// decreasing order. Joltages of equal length compare like their digit
+// strings, so trying digits from 9 down at every step visits them in order.
+// Taking the leftmost occurrence of a digit leaves the most room for the
+// rest, which makes each joltage reachable through exactly one path, and a
+// digit is only tried when enough batteries follow it, so every branch ends
+// in a joltage and each one costs at most 10*count probes.
+func topSelections(bank string, count, k int) []Selection {
+	var positions [10][]int
+	for i := 0; i < len(bank); i++ {
+		d := bank[i] - '0'
+		positions[d] = append(positions[d], i)
+	}
+
+	var res []Selection
+	chosen := make([]int, 0, count)
+	var walk func(pos int) bool
+	walk = func(pos int) bool {
+		if len(chosen) == count {
+			res = append(res, newSelection(bank, slices.Clone(chosen)))
+			return len(res) < k
+		}
+		left := count - len(chosen)
+		for d := 9; d >= 0; d-- {
+			ps := positions[d]
+			i := sort.SearchInts(ps, pos)
+			if i == len(ps) || len(bank)-ps[i] < left {
+				continue
+			}
+			chosen = append(chosen, ps[i])
+			more := walk(ps[i] + 1)
+			chosen = chosen[:len(chosen)-1]
+			if !more {
+				return false
+			}
+		}
+		return true
+	}
+	walk(0)
+	return res
+}
@@ -0,0 +1,148 @@
+package day3
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+	"text/tabwriter"
+)
+
+// highlightOn and highlightOff wrap the chosen digits in reverse video.
+const (
+	highlightOn  = "\x1b[7m"
+	highlightOff = "\x1b[0m"
+)
+
+// Selection explains how the joltage of one bank was formed.
+type Selection struct {
+	// Line is the 1-based input line of the bank, or 0 from Explain.
+	Line int    `json:"line"`
+	Bank string `json:"bank"`
+	// Chosen and Dropped list, in ascending order, the 0-based positions of
+	// the batteries turned on and left off.
+	Chosen  []int  `json:"chosen"`
+	Dropped []int  `json:"dropped"`
+	Joltage string `json:"joltage"`
+}
+
+// Report lists the selection of every bank, in input order.
+type Report struct {
+	Banks []Selection `json:"banks"`
+	Total *big.Int    `json:"total"`
+}
+
+// Explain returns the selection giving the largest joltage of the bank with
+// the given number of batteries.
+func Explain(bank string, batteries int) (Selection, error) {
+	count, err := Options{Batteries: batteries}.batteries()
+	if err != nil {
+		return Selection{}, err
+	}
+	chosen, err := selectBatteries(bank, count)
+	if err != nil {
+		return Selection{}, err
+	}
+	return newSelection(bank, chosen), nil
+}
+
+// newSelection describes the batteries at the chosen positions of the bank.
+func newSelection(bank string, chosen []int) Selection {
+	sel := Selection{Bank: bank, Chosen: chosen, Dropped: make([]int, 0, len(bank)-len(chosen))}
+	joltage := make([]byte, len(chosen))
+	next := 0
+	for i := range bank {
+		if next < len(chosen) && chosen[next] == i {
+			joltage[next] = bank[i]
+			next++
+			continue
+		}
+		sel.Dropped = append(sel.Dropped, i)
+	}
+	sel.Joltage = string(joltage)
+	return sel
+}
+
+// ComputeReport reads the banks like ComputeWith and explains the selection
+// made in each of them.
+func ComputeReport(r io.Reader, opts Options) (Report, error) {
+	count, err := opts.batteries()
+	if err != nil {
+		return Report{}, err
+	}
+
+	rep := Report{Total: new(big.Int)}
+	_, err = eachBank(r, func(line int, bank string) error {
+		sel, err := Explain(bank, count)
+		if err != nil {
+			return err
+		}
+		sel.Line = line
+		rep.Banks = append(rep.Banks, sel)
+
+		value, _ := new(big.Int).SetString(sel.Joltage, 10)
+		rep.Total.Add(rep.Total, value)
+		return nil
+	})
+	if err != nil {
+		return Report{}, err
+	}
+	return rep, nil
+}
+
+// Highlight returns the bank with the chosen digits in reverse video.
+func (s Selection) Highlight() string {
+	var b strings.Builder
+	next := 0
+	for i := 0; i < len(s.Bank); i++ {
+		if next < len(s.Chosen) && s.Chosen[next] == i {
+			// Group consecutive chosen digits under one escape sequence.
+			end := i
+			for next < len(s.Chosen) && s.Chosen[next] == end {
+				next++
+				end++
+			}
+			b.WriteString(highlightOn)
+			b.WriteString(s.Bank[i:end])
+			b.WriteString(highlightOff)
+			i = end - 1
+			continue
+		}
+		b.WriteByte(s.Bank[i])
+	}
+	return b.String()
+}
+
+// WriteHighlight prints every bank with its chosen digits highlighted next to
+// the resulting joltage, followed by the total, as in the puzzle example.
+func (rep Report) WriteHighlight(w io.Writer) error {
+	width := len("Total")
+	for _, s := range rep.Banks {
+		width = max(width, len(s.Bank))
+	}
+	for _, s := range rep.Banks {
+		padding := strings.Repeat(" ", width-len(s.Bank))
+		if _, err := fmt.Fprintf(w, "%s%s -> %s\n", s.Highlight(), padding, s.Joltage); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "%-*s -> %s\n", width, "Total", rep.Total)
+	return err
+}
+
+// WriteTable prints one row per bank with the dropped batteries shown as dots.
+func (rep Report) WriteTable(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "LINE\tSELECTED\tJOLTAGE\tDROPPED")
+	for _, s := range rep.Banks {
+		selected := []byte(s.Bank)
+		dropped := make([]string, len(s.Dropped))
+		for i, idx := range s.Dropped {
+			selected[idx] = '.'
+			dropped[i] = fmt.Sprint(idx)
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\n", s.Line, selected, s.Joltage, strings.Join(dropped, ","))
+	}
+	fmt.Fprintf(tw, "TOTAL\t\t%s\n", rep.Total)
+	return tw.Flush()
+}
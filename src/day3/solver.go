@@ -0,0 +1,72 @@
+package day3
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"adventofcode2025/day1/src/aoc"
+)
+
+// solver adds a per-bank selection report to the registered parts.
+type solver struct {
+	aoc.Solver
+}
+
+// partOptions holds the battery count of each part.
+var partOptions = map[int]Options{
+	1: {Batteries: 2},
+	2: {Batteries: DefaultBatteries},
+}
+
+func init() {
+	aoc.Register(3, solver{aoc.Parts{1: solvePart1, 2: solvePart2}})
+}
+
+func solvePart1(r io.Reader) (string, error) {
+	return solve(r, partOptions[1])
+}
+
+func solvePart2(r io.Reader) (string, error) {
+	return solve(r, partOptions[2])
+}
+
+func solve(r io.Reader, opts Options) (string, error) {
+	result, err := ComputeWith(r, opts)
+	if err != nil {
+		return "", err
+	}
+	if result.BigTotal != nil {
+		return result.BigTotal.String(), nil
+	}
+	return fmt.Sprintf("%d", result.Total), nil
+}
+
+// Report writes the selected batteries of part as a "table" (the default), as
+// "highlight"ed banks or as "json".
+func (solver) Report(part int, r io.Reader, w io.Writer, format string) error {
+	opts, ok := partOptions[part]
+	if !ok {
+		return fmt.Errorf("%w: %d", aoc.ErrUnknownPart, part)
+	}
+	if format == "" {
+		format = "table"
+	}
+	if format != "table" && format != "highlight" && format != "json" {
+		return fmt.Errorf("%w: %q", aoc.ErrUnknownFormat, format)
+	}
+
+	rep, err := ComputeReport(r, opts)
+	if err != nil {
+		return err
+	}
+	switch format {
+	case "table":
+		return rep.WriteTable(w)
+	case "highlight":
+		return rep.WriteHighlight(w)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(rep)
+}
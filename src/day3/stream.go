@@ -0,0 +1,248 @@
+package day3
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+var (
+	ErrInvalidHeader = errors.New("invalid bank header")
+	ErrBankLength    = errors.New("bank length does not match its header")
+)
+
+// ComputeSeekable is ComputeWith for banks of any length. The greedy
+// selection needs to know how many batteries a bank holds before reading it,
+// so a first pass over r measures every bank and a second pass, after seeking
+// back to where r started, selects the batteries. Memory grows with the number
+// of batteries and banks, not with the length of a bank.
+func ComputeSeekable(r io.ReadSeeker, opts Options) (Result, error) {
+	count, err := opts.batteries()
+	if err != nil {
+		return Result{}, err
+	}
+	start, err := r.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return Result{}, err
+	}
+
+	var lengths []int
+	br := newBankReader(r, false)
+	for {
+		n := 0
+		ok, err := br.scan(nil, func(byte) { n++ })
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return Result{}, fmt.Errorf("line %d: %w", br.line, err)
+		}
+		if ok {
+			lengths = append(lengths, n)
+		}
+	}
+	if len(lengths) == 0 {
+		return Result{}, ErrNoBanks
+	}
+
+	if _, err := r.Seek(start, io.SeekStart); err != nil {
+		return Result{}, err
+	}
+	next := 0
+	return streamBanks(newBankReader(r, false), count, func() int {
+		if next == len(lengths) {
+			// The input grew between the two passes.
+			return -1
+		}
+		next++
+		return lengths[next-1]
+	})
+}
+
+// ComputeStream is ComputeWith for banks of any length read in a single pass.
+// Every bank must be preceded by its number of batteries and a colon, as in
+// "15:987654321111111", since the greedy selection needs it up front.
+func ComputeStream(r io.Reader, opts Options) (Result, error) {
+	count, err := opts.batteries()
+	if err != nil {
+		return Result{}, err
+	}
+	return streamBanks(newBankReader(r, true), count, nil)
+}
+
+// streamBanks selects the batteries of every bank of br, one digit at a time.
+// length returns the size of the next bank, or -1 if unknown, when br has no
+// headers.
+func streamBanks(br *bankReader, count int, length func() int) (Result, error) {
+	var sum joltageSum
+	sel := &streamSelector{stack: make([]byte, 0, count)}
+	for {
+		declared := 0
+		begin := func(header int) error {
+			declared = header
+			if length != nil {
+				declared = length()
+			}
+			if declared < 0 {
+				return fmt.Errorf("%w: input changed between passes", ErrBankLength)
+			}
+			if declared < count {
+				return fmt.Errorf("%w: %d batteries, need %d", ErrBankTooShort, declared, count)
+			}
+			sel.reset(count, declared)
+			return nil
+		}
+		seen := 0
+		ok, err := br.scan(begin, func(d byte) {
+			seen++
+			sel.push(d)
+		})
+		if err == io.EOF {
+			break
+		}
+		if err == nil && ok && seen != declared {
+			err = fmt.Errorf("%w: %d batteries, header says %d", ErrBankLength, seen, declared)
+		}
+		if err != nil {
+			return Result{}, fmt.Errorf("line %d: %w", br.line, err)
+		}
+		if ok {
+			sum.add(sel.stack)
+		}
+	}
+	if sum.banks == 0 {
+		return Result{}, ErrNoBanks
+	}
+	return sum.result(), nil
+}
+
+// streamSelector is the monotonic stack of selectBatteries capped at count
+// digits. A digit that would land past the cap can only be popped or trimmed
+// later on, so it is dropped right away, which leaves the same drop budget
+// for the digits that matter.
+type streamSelector struct {
+	count  int
+	toDrop int
+	stack  []byte
+}
+
+func (s *streamSelector) reset(count, length int) {
+	s.count = count
+	s.toDrop = length - count
+	s.stack = s.stack[:0]
+}
+
+func (s *streamSelector) push(d byte) {
+	for s.toDrop > 0 && len(s.stack) > 0 && s.stack[len(s.stack)-1] < d {
+		s.stack = s.stack[:len(s.stack)-1]
+		s.toDrop--
+	}
+	if len(s.stack) < s.count {
+		s.stack = append(s.stack, d)
+		return
+	}
+	s.toDrop--
+}
+
+// bankReader reads banks byte by byte so that no bank has to fit in memory.
+type bankReader struct {
+	r *bufio.Reader
+	// header is set when every bank starts with "<length>:".
+	header bool
+	line   int
+}
+
+func newBankReader(r io.Reader, header bool) *bankReader {
+	return &bankReader{r: bufio.NewReader(r), header: header}
+}
+
+// scan reads the next line. For a non-blank line it calls begin, when set,
+// with the length from the header (0 without headers) and then digit with
+// the value of every battery, and reports true. Surrounding whitespace is
+// ignored as in ComputeWith. It returns io.EOF once the input is exhausted.
+func (b *bankReader) scan(begin func(header int) error, digit func(d byte)) (bool, error) {
+	b.line++
+	const (
+		leading = iota
+		header
+		digits
+		trailing
+	)
+	state := leading
+	length := 0
+	read := false
+
+	for {
+		c, err := b.r.ReadByte()
+		if err == io.EOF {
+			if !read {
+				return false, io.EOF
+			}
+			break
+		}
+		if err != nil {
+			return false, err
+		}
+		read = true
+		if c == '\n' {
+			break
+		}
+
+		space := c == ' ' || c == '\t' || c == '\r' || c == '\v' || c == '\f'
+		switch state {
+		case leading:
+			if space {
+				continue
+			}
+			if b.header {
+				state = header
+				if c < '0' || c > '9' {
+					return false, fmt.Errorf("%w: %q", ErrInvalidHeader, c)
+				}
+				length = int(c - '0')
+				continue
+			}
+			if begin != nil {
+				if err := begin(0); err != nil {
+					return false, err
+				}
+			}
+			state = digits
+			fallthrough
+		case digits:
+			if space {
+				state = trailing
+				continue
+			}
+			if c < '0' || c > '9' {
+				return false, fmt.Errorf("%w: %q", ErrInvalidDigit, c)
+			}
+			digit(c - '0')
+		case header:
+			if c == ':' {
+				if begin != nil {
+					if err := begin(length); err != nil {
+						return false, err
+					}
+				}
+				state = digits
+				continue
+			}
+			if c < '0' || c > '9' || length > (math.MaxInt-9)/10 {
+				return false, fmt.Errorf("%w: %q", ErrInvalidHeader, c)
+			}
+			length = length*10 + int(c-'0')
+		case trailing:
+			if !space {
+				return false, fmt.Errorf("%w: %q", ErrInvalidDigit, c)
+			}
+		}
+	}
+
+	if state == header {
+		return false, fmt.Errorf("%w: missing ':'", ErrInvalidHeader)
+	}
+	return state != leading, nil
+}
@@ -16,12 +16,43 @@ var (
 
 type Result struct {
 	TotalRemoved int
+	// Waves przechowuje liczbę kulek usuniętych w kolejnych falach: Waves[0]
+	// to kulki dostępne w początkowej siatce (odpowiedź części 1).
+	Waves []int
+	// Survivors to siatka po usunięciu kulek w formacie '.'/'@', ustawiana
+	// tylko przy Options.Survivors.
+	Survivors []string
+	// RemovedIn podaje dla każdej komórki numer fali (od 1), w której usunięto
+	// jej kulkę, albo Empty lub Survivor. Ustawiane tylko przy
+	// Options.RemovalOrder.
+	RemovedIn [][]int
 }
 
+// Wartości RemovedIn dla komórek, z których nic nie usunięto.
+const (
+	// Empty oznacza komórkę bez kulki ('.').
+	Empty = 0
+	// Survivor oznacza kulkę, która przetrwała wszystkie fale.
+	Survivor = -1
+)
+
 // Compute odczytuje siatkę znaków '.' i '@' i wielokrotnie usuwa 'kulki' ('@'),
 // które mają mniej niż 4 sąsiadów '@' w 8 sąsiednich pozycjach, aż nie będzie
 // można usunąć więcej kulek. Zwraca łączną liczbę usuniętych kulek.
 func Compute(r io.Reader) (Result, error) {
+	return ComputeWith(r, Options{})
+}
+
+// ComputeWith działa jak Compute, ale usuwa kulki falami: w każdej fali
+// znikają jednocześnie wszystkie kulki, które mają mniej niż k sąsiadów na
+// jej początku. Liczba kulek usuniętych w każdej fali trafia do Result.Waves.
+// Próg k, kształt sąsiedztwa i zawijanie krawędzi pochodzą z opts.
+func ComputeWith(r io.Reader, opts Options) (Result, error) {
+	opts, err := opts.normalize()
+	if err != nil {
+		return Result{}, err
+	}
+
 	// Odczyt wiersz po wierszu wejścia, z pominięciem pustych linii.
 	// Używamy bufio.Scanner z powiększonym buforem, aby obsłużyć duże
 	// siatki, jeśli to konieczne.
@@ -74,9 +105,6 @@ func Compute(r io.Reader) (Result, error) {
 
 	// `present` oznacza, czy komórka nadal zawiera '@'
 	present := make([]bool, h*w)
-	// `degree` przechowuje aktualną liczbę sąsiadów '@' dla każdej komórki
-	degree := make([]int, h*w)
-
 	for y := 0; y < h; y++ {
 		for x := 0; x < w; x++ {
 			if grid[y][x] == '@' {
@@ -85,89 +113,140 @@ func Compute(r io.Reader) (Result, error) {
 		}
 	}
 
-	for y := 0; y < h; y++ {
-		for x := 0; x < w; x++ {
-			idx := y*w + x
-			// Jeśli komórka nie jest obecna, pomijamy obliczenia
-			if !present[idx] {
-				continue
-			}
-			// Zlicza sąsiadów '@' wokół komórki (8 kierunków)
-			neighbors := 0
-			for dy := -1; dy <= 1; dy++ {
-				ny := y + dy
-				if ny < 0 || ny >= h {
-					continue
+	l := lattice{h: h, w: w, offsets: opts.Neighborhood, wrap: opts.Wrap}
+	res, removedIn := peel(present, l.neighbors, opts)
+
+	if opts.Survivors {
+		res.Survivors = make([]string, h)
+		for y := range res.Survivors {
+			row := make([]byte, w)
+			for x := range row {
+				row[x] = '.'
+				if present[y*w+x] {
+					row[x] = '@'
 				}
-				for dx := -1; dx <= 1; dx++ {
-					if dx == 0 && dy == 0 {
-						continue
-					}
-					nx := x + dx
-					if nx < 0 || nx >= w {
-						continue
-					}
-					if present[ny*w+nx] {
-						neighbors++
-					}
+			}
+			res.Survivors[y] = string(row)
+		}
+	}
+	if opts.RemovalOrder {
+		res.RemovedIn = make([][]int, h)
+		for y := range res.RemovedIn {
+			row := removedIn[y*w : (y+1)*w : (y+1)*w]
+			for x := range row {
+				if present[y*w+x] {
+					row[x] = Survivor
 				}
 			}
-			degree[idx] = neighbors
+			res.RemovedIn[y] = row
 		}
 	}
 
-	// Buduje początkową kolejkę komórek, które mają mniej niż 4 sąsiadów i
-	// dlatego powinny zostać usunięte w iteracji.
-	queue := make([]int, 0, h*w)
+	return res, nil
+}
+
+// neighborFunc wywołuje fn dla każdego sąsiada komórki idx, czyli komórki
+// idx+offset dla sign = 1 albo idx-offset dla sign = -1.
+type neighborFunc func(idx, sign int, fn func(nidx int))
+
+// peel usuwa kulki z `present` falami i zwraca wynik wraz z numerem fali
+// każdej usuniętej komórki (tylko przy opts.RemovalOrder). Po powrocie
+// `present` zawiera kulki, które przetrwały.
+func peel(present []bool, neighbors neighborFunc, opts Options) (Result, []int) {
+	// `degree` przechowuje aktualną liczbę sąsiadów '@' dla każdej komórki
+	degree := make([]int, len(present))
 	for idx, ok := range present {
-		if ok && degree[idx] < 4 {
-			queue = append(queue, idx)
+		// Jeśli komórka nie jest obecna, pomijamy obliczenia
+		if !ok {
+			continue
 		}
+		// Zlicza sąsiadów '@' wokół komórki
+		neighbors(idx, 1, func(nidx int) {
+			if present[nidx] {
+				degree[idx]++
+			}
+		})
 	}
 
-	// Przetwarzamy kolejkę, usuwając kwalifikujące się komórki i aktualizując
-	// stopnie ich sąsiadów — jeśli sąsiad spadnie poniżej 4, zostaje dodany
-	// do kolejki. To przypomina algorytm obliczania k-core (tutaj k = 4)
-	// dla grafu.
-	removed := 0
-	for len(queue) > 0 {
-		idx := queue[0]
-		queue = queue[1:]
-		// Sprawdza, czy komórka nadal jest obecna i wymaga usunięcia
-		if !present[idx] || degree[idx] >= 4 {
-			continue
+	k := opts.Threshold
+	// Pierwsza fala to wszystkie kulki, które mają mniej niż k sąsiadów w
+	// początkowej siatce.
+	var wave []int
+	for idx, ok := range present {
+		if ok && degree[idx] < k {
+			wave = append(wave, idx)
 		}
-		// Usuwa komórkę i zwiększa licznik
-		present[idx] = false
-		removed++
-		// Aktualizuje sąsiadów
-		y := idx / w
-		x := idx % w
-		for dy := -1; dy <= 1; dy++ {
-			ny := y + dy
-			if ny < 0 || ny >= h {
-				continue
+	}
+
+	// `removedIn` zapamiętuje numer fali każdej usuniętej komórki, jeśli
+	// trzeba zwrócić kolejność usuwania
+	var removedIn []int
+	if opts.RemovalOrder {
+		removedIn = make([]int, len(present))
+	}
+
+	// Przetwarzamy fale po kolei, usuwając ich komórki i aktualizując stopnie
+	// komórek, dla których były sąsiadami — komórka, która spadnie poniżej k,
+	// trafia do następnej fali. To przypomina algorytm obliczania k-core dla
+	// grafu, z podziałem na rundy.
+	res := Result{}
+	for len(wave) > 0 {
+		// Usuwa wszystkie komórki fali naraz
+		for _, idx := range wave {
+			present[idx] = false
+			if removedIn != nil {
+				removedIn[idx] = len(res.Waves) + 1
 			}
-			for dx := -1; dx <= 1; dx++ {
-				if dx == 0 && dy == 0 {
-					continue
-				}
-				nx := x + dx
-				if nx < 0 || nx >= w {
-					continue
-				}
-				nidx := ny*w + nx
+		}
+		res.Waves = append(res.Waves, len(wave))
+		res.TotalRemoved += len(wave)
+		if opts.FirstWaveOnly {
+			break
+		}
+
+		var next []int
+		for _, idx := range wave {
+			// Sąsiedztwo nie musi być symetryczne, więc aktualizujemy komórki
+			// leżące o przesunięcie wstecz.
+			neighbors(idx, -1, func(nidx int) {
 				if !present[nidx] {
-					continue
+					return
 				}
 				degree[nidx]--
-				// Si ce voisin tombe sous le seuil, il sera supprimé aussi
-				if degree[nidx] < 4 {
-					queue = append(queue, nidx)
+				// Si ce voisin tombe sous le seuil, il sera supprimé à la
+				// vague suivante (une seule fois, au franchissement)
+				if degree[nidx] == k-1 {
+					next = append(next, nidx)
 				}
-			}
+			})
 		}
+		wave = next
 	}
+	return res, removedIn
+}
 
-	return Result{TotalRemoved: removed}, nil
+// lattice opisuje geometrię siatki h×w z komórkami indeksowanymi y*w+x.
+type lattice struct {
+	h, w    int
+	offsets []Offset
+	wrap    bool
+}
+
+// neighbors wywołuje fn dla każdej komórki idx+sign*offset leżącej w siatce
+// (lub zawiniętej na torusie). Na małym torusie ta sama komórka może wystąpić
+// kilka razy i jest wtedy liczona wielokrotnie.
+func (l lattice) neighbors(idx, sign int, fn func(nidx int)) {
+	y := idx / l.w
+	x := idx % l.w
+	for _, off := range l.offsets {
+		ny := y + sign*off.DY
+		nx := x + sign*off.DX
+		if l.wrap {
+			ny = ((ny % l.h) + l.h) % l.h
+			nx = ((nx % l.w) + l.w) % l.w
+		} else if ny < 0 || ny >= l.h || nx < 0 || nx >= l.w {
+			continue
+		}
+		fn(ny*l.w + nx)
+	}
 }
@@ -0,0 +1,205 @@
+package day4
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var ErrInvalidDimensions = errors.New("invalid number of dimensions")
+
+// MaxDims to największa obsługiwana liczba wymiarów.
+const MaxDims = 4
+
+// ComputeLayers działa jak ComputeWith dla siatki o dims wymiarach (od 2 do
+// MaxDims) zapisanej warstwami. Warstwy 3D to zwykłe siatki '.'/'@'
+// oddzielone pustą linią; w 4D kolejne stosy warstw oddziela co najmniej
+// jedna dodatkowa pusta linia; siatka 2D nie może zawierać pustych linii
+// między wierszami. Sąsiadami są wszystkie komórki w odległości
+// Czebyszewa 1, a Wrap zawija każdą oś.
+func ComputeLayers(r io.Reader, dims int, opts Options) (Result, error) {
+	if dims < 2 || dims > MaxDims {
+		return Result{}, fmt.Errorf("%w: %d", ErrInvalidDimensions, dims)
+	}
+	opts, err := normalizeND(opts)
+	if err != nil {
+		return Result{}, err
+	}
+
+	size, present, err := readLayers(r, dims)
+	if err != nil {
+		return Result{}, err
+	}
+	b := box{size: size, offsets: chebyshevOffsets(dims), wrap: opts.Wrap}
+	res, _ := peel(present, b.neighbors, opts)
+	return res, nil
+}
+
+// ComputeTuples działa jak ComputeSparse dla kulek podanych jako krotki
+// "x,y,z" lub "x,y,z,w" (od 2 do MaxDims współrzędnych, tyle samo w każdej
+// linii). Sąsiadami są wszystkie komórki w odległości Czebyszewa 1.
+func ComputeTuples(r io.Reader, opts Options) (Result, error) {
+	opts, err := normalizeND(opts)
+	if err != nil {
+		return Result{}, err
+	}
+	if opts.Wrap {
+		return Result{}, fmt.Errorf("%w: sparse grids cannot wrap", ErrInvalidOptions)
+	}
+
+	cells, index, dims, err := readCells(r, 0)
+	if err != nil {
+		return Result{}, err
+	}
+	return peelCells(cells, index, chebyshevOffsets(dims), opts), nil
+}
+
+// normalizeND sprawdza opcje dla siatek wielowymiarowych, w których
+// sąsiedztwo jest stałe, a siatki i mapy fal nie mają formatu wyjściowego.
+func normalizeND(opts Options) (Options, error) {
+	if len(opts.Neighborhood) > 0 || opts.Survivors || opts.RemovalOrder {
+		return Options{}, fmt.Errorf("%w: N-dimensional grids only support Threshold, Wrap and FirstWaveOnly", ErrInvalidOptions)
+	}
+	return opts.normalize()
+}
+
+// chebyshevOffsets zwraca 3^dims-1 przesunięć do komórek w odległości
+// Czebyszewa 1.
+func chebyshevOffsets(dims int) []cell {
+	offsets := []cell{{}}
+	for a := 0; a < dims; a++ {
+		next := make([]cell, 0, len(offsets)*3)
+		for _, off := range offsets {
+			for d := -1; d <= 1; d++ {
+				off[a] = d
+				next = append(next, off)
+			}
+		}
+		offsets = next
+	}
+	// Usuwa przesunięcie zerowe (komórka nie jest swoim sąsiadem)
+	res := offsets[:0]
+	for _, off := range offsets {
+		if off != (cell{}) {
+			res = append(res, off)
+		}
+	}
+	return res
+}
+
+// box opisuje geometrię gęstej siatki wielowymiarowej; oś 0 (x) zmienia się
+// najszybciej w indeksie komórki.
+type box struct {
+	size    []int
+	offsets []cell
+	wrap    bool
+}
+
+// neighbors jest odpowiednikiem lattice.neighbors dla dowolnej liczby osi.
+func (b box) neighbors(idx, sign int, fn func(nidx int)) {
+	var c cell
+	for a, n := range b.size {
+		c[a] = idx % n
+		idx /= n
+	}
+
+next:
+	for _, off := range b.offsets {
+		nidx, stride := 0, 1
+		for a, n := range b.size {
+			v := c[a] + sign*off[a]
+			if b.wrap {
+				v = ((v % n) + n) % n
+			} else if v < 0 || v >= n {
+				continue next
+			}
+			nidx += v * stride
+			stride *= n
+		}
+		fn(nidx)
+	}
+}
+
+// readLayers odczytuje siatkę warstwową i zwraca jej rozmiar wzdłuż każdej
+// osi oraz zajętość komórek.
+func readLayers(r io.Reader, dims int) ([]int, []bool, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 1024), 1024*1024)
+
+	// `stacks` to stosy warstw, a warstwa to lista wierszy
+	var stacks [][][]string
+	width := -1
+	blanks := 0
+	line := 0
+
+	for scanner.Scan() {
+		line++
+		raw := strings.TrimSpace(scanner.Text())
+		if raw == "" {
+			blanks++
+			continue
+		}
+		if width == -1 {
+			width = len(raw)
+		}
+		if len(raw) != width {
+			return nil, nil, fmt.Errorf("line %d: %w", line, ErrNonRectangular)
+		}
+		for col, ch := range []byte(raw) {
+			if ch != '.' && ch != '@' {
+				return nil, nil, fmt.Errorf("line %d col %d: %w: %q", line, col+1, ErrInvalidCellRune, ch)
+			}
+		}
+
+		// Puste linie zaczynają nową warstwę (3D, 4D) lub nowy stos (4D)
+		switch {
+		case dims == 2 && len(stacks) > 0 && blanks > 0:
+			return nil, nil, fmt.Errorf("line %d: %w: blank line inside a 2D grid", line, ErrInvalidDimensions)
+		case len(stacks) == 0 || (dims == 4 && blanks >= 2):
+			stacks = append(stacks, [][]string{{}})
+		case dims >= 3 && blanks >= 1:
+			last := len(stacks) - 1
+			stacks[last] = append(stacks[last], []string{})
+		}
+		blanks = 0
+
+		stack := stacks[len(stacks)-1]
+		stack[len(stack)-1] = append(stack[len(stack)-1], raw)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, nil, err
+	}
+	if len(stacks) == 0 {
+		return nil, nil, ErrNoGrid
+	}
+
+	// Wszystkie warstwy muszą mieć tę samą wysokość, a stosy tyle samo warstw
+	height := len(stacks[0][0])
+	depth := len(stacks[0])
+	for _, stack := range stacks {
+		if len(stack) != depth {
+			return nil, nil, fmt.Errorf("%w: stacks have different numbers of layers", ErrNonRectangular)
+		}
+		for _, layer := range stack {
+			if len(layer) != height {
+				return nil, nil, fmt.Errorf("%w: layers have different heights", ErrNonRectangular)
+			}
+		}
+	}
+
+	size := []int{width, height, depth, len(stacks)}[:dims]
+	present := make([]bool, 0, width*height*depth*len(stacks))
+	for _, stack := range stacks {
+		for _, layer := range stack {
+			for _, row := range layer {
+				for x := 0; x < width; x++ {
+					present = append(present, row[x] == '@')
+				}
+			}
+		}
+	}
+	return size, present, nil
+}
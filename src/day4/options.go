@@ -0,0 +1,86 @@
+package day4
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidOptions = errors.New("invalid options")
+
+// DefaultThreshold to liczba sąsiadów, od której kulka przestaje być dostępna
+// w łamigłówce.
+const DefaultThreshold = 4
+
+// Offset to przesunięcie od komórki do jednego z jej sąsiadów.
+type Offset struct {
+	DX, DY int
+}
+
+// Options konfiguruje ComputeWith.
+type Options struct {
+	// FirstWaveOnly zatrzymuje usuwanie po pierwszej fali, tak aby
+	// TotalRemoved liczyło tylko kulki dostępne w początkowej siatce.
+	FirstWaveOnly bool
+	// Threshold to k: kulka jest usuwana, gdy ma mniej niż k sąsiadów. Zero
+	// oznacza DefaultThreshold.
+	Threshold int
+	// Neighborhood wymienia przesunięcia sąsiadów komórki. Puste oznacza
+	// Moore(), czyli 8 sąsiednich pozycji.
+	Neighborhood []Offset
+	// Wrap skleja przeciwległe krawędzie siatki (torus).
+	Wrap bool
+	// Survivors zwraca w Result.Survivors siatkę po usunięciu kulek.
+	Survivors bool
+	// RemovalOrder zwraca w Result.RemovedIn numer fali każdej komórki.
+	RemovalOrder bool
+}
+
+// Moore zwraca 8 pozycji otaczających komórkę.
+func Moore() []Offset {
+	return Radius(1)
+}
+
+// VonNeumann zwraca 4 pozycje stykające się z komórką bokiem.
+func VonNeumann() []Offset {
+	return []Offset{{0, -1}, {-1, 0}, {1, 0}, {0, 1}}
+}
+
+// Radius zwraca wszystkie pozycje w odległości Czebyszewa co najwyżej r od
+// komórki, bez niej samej.
+func Radius(r int) []Offset {
+	var offsets []Offset
+	for dy := -r; dy <= r; dy++ {
+		for dx := -r; dx <= r; dx++ {
+			if dx != 0 || dy != 0 {
+				offsets = append(offsets, Offset{dx, dy})
+			}
+		}
+	}
+	return offsets
+}
+
+// normalize sprawdza opcje i uzupełnia wartości domyślne.
+func (o Options) normalize() (Options, error) {
+	switch {
+	case o.Threshold < 0:
+		return Options{}, fmt.Errorf("%w: threshold %d", ErrInvalidOptions, o.Threshold)
+	case o.Threshold == 0:
+		o.Threshold = DefaultThreshold
+	}
+	if len(o.Neighborhood) == 0 {
+		o.Neighborhood = Moore()
+		return o, nil
+	}
+
+	seen := make(map[Offset]bool, len(o.Neighborhood))
+	for _, off := range o.Neighborhood {
+		if off == (Offset{}) {
+			return Options{}, fmt.Errorf("%w: a cell cannot be its own neighbour", ErrInvalidOptions)
+		}
+		if seen[off] {
+			return Options{}, fmt.Errorf("%w: duplicate offset %v", ErrInvalidOptions, off)
+		}
+		seen[off] = true
+	}
+	return o, nil
+}
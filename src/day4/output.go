@@ -0,0 +1,44 @@
+package day4
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// WriteSurvivors zapisuje siatkę po usunięciu kulek, wiersz po wierszu, w
+// tym samym formacie co wejście.
+func (res Result) WriteSurvivors(w io.Writer) error {
+	for _, row := range res.Survivors {
+		if _, err := fmt.Fprintln(w, row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteRemovalOrder zapisuje mapę fal: numer fali dla usuniętych kulek, '@'
+// dla kulek, które przetrwały, i '.' dla pustych komórek. Kolumny są
+// wyrównane do najdłuższego numeru fali.
+func (res Result) WriteRemovalOrder(w io.Writer) error {
+	width := len(strconv.Itoa(len(res.Waves)))
+	cells := make([]string, 0)
+	for _, row := range res.RemovedIn {
+		cells = cells[:0]
+		for _, wave := range row {
+			cell := strconv.Itoa(wave)
+			switch wave {
+			case Empty:
+				cell = "."
+			case Survivor:
+				cell = "@"
+			}
+			cells = append(cells, fmt.Sprintf("%*s", width, cell))
+		}
+		if _, err := fmt.Fprintln(w, strings.Join(cells, " ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package day4
+
+import (
+	"fmt"
+	"io"
+
+	"adventofcode2025/day1/src/aoc"
+)
+
+// solver dodaje do zarejestrowanych części zapis siatki końcowej i kolejności
+// usuwania.
+type solver struct {
+	aoc.Solver
+}
+
+// partOptions przechowuje opcje każdej części.
+var partOptions = map[int]Options{
+	1: {FirstWaveOnly: true},
+	2: {},
+}
+
+func init() {
+	aoc.Register(4, solver{aoc.Parts{1: solvePart1, 2: solvePart2}})
+}
+
+func solvePart1(r io.Reader) (string, error) {
+	result, err := ComputeWith(r, partOptions[1])
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d", result.TotalRemoved), nil
+}
+
+func solvePart2(r io.Reader) (string, error) {
+	result, err := ComputeWith(r, partOptions[2])
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d", result.TotalRemoved), nil
+}
+
+// Report zapisuje siatkę końcowej części ("grid", domyślnie) albo mapę fal
+// ("waves").
+func (solver) Report(part int, r io.Reader, w io.Writer, format string) error {
+	opts, ok := partOptions[part]
+	if !ok {
+		return fmt.Errorf("%w: %d", aoc.ErrUnknownPart, part)
+	}
+	if format == "" {
+		format = "grid"
+	}
+	switch format {
+	case "grid":
+		opts.Survivors = true
+	case "waves":
+		opts.RemovalOrder = true
+	default:
+		return fmt.Errorf("%w: %q", aoc.ErrUnknownFormat, format)
+	}
+
+	result, err := ComputeWith(r, opts)
+	if err != nil {
+		return err
+	}
+	if format == "grid" {
+		return result.WriteSurvivors(w)
+	}
+	return result.WriteRemovalOrder(w)
+}
@@ -0,0 +1,139 @@
+package day4
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidCoordinate = errors.New("invalid coordinate")
+	ErrDuplicateCell     = errors.New("duplicate cell")
+)
+
+// cell to współrzędne kulki w siatce rzadkiej; nieużywane wymiary są zerami.
+type cell [MaxDims]int
+
+// ComputeSparse działa jak ComputeWith dla siatki podanej jako lista kulek,
+// po jednej parze "x,y" w wierszu. Pamięć zależy tylko od liczby kulek, a nie
+// od rozmiarów siatki, bo sąsiadów szukamy w mapie współrzędnych. Siatka
+// rzadka nie ma krawędzi, więc opcje Wrap, Survivors i RemovalOrder nie są
+// obsługiwane.
+func ComputeSparse(r io.Reader, opts Options) (Result, error) {
+	opts, err := opts.normalize()
+	if err != nil {
+		return Result{}, err
+	}
+	if opts.Wrap || opts.Survivors || opts.RemovalOrder {
+		return Result{}, fmt.Errorf("%w: sparse grids only support Threshold, Neighborhood and FirstWaveOnly", ErrInvalidOptions)
+	}
+
+	cells, index, _, err := readCells(r, 2)
+	if err != nil {
+		return Result{}, err
+	}
+	offsets := make([]cell, len(opts.Neighborhood))
+	for i, off := range opts.Neighborhood {
+		offsets[i] = cell{off.DX, off.DY}
+	}
+	return peelCells(cells, index, offsets, opts), nil
+}
+
+// peelCells usuwa kulki siatki rzadkiej, szukając sąsiadów w mapie `index`.
+func peelCells(cells []cell, index map[cell]int, offsets []cell, opts Options) Result {
+	neighbors := func(idx, sign int, fn func(nidx int)) {
+	next:
+		for _, off := range offsets {
+			n := cells[idx]
+			for a := range n {
+				v, ok := shift(n[a], off[a], sign)
+				if !ok {
+					// Sąsiad leżałby poza zakresem int, więc nie istnieje
+					continue next
+				}
+				n[a] = v
+			}
+			if nidx, ok := index[n]; ok {
+				fn(nidx)
+			}
+		}
+	}
+
+	// Wszystkie znane komórki są na początku zajęte
+	present := make([]bool, len(cells))
+	for i := range present {
+		present[i] = true
+	}
+	res, _ := peel(present, neighbors, opts)
+	return res
+}
+
+// shift zwraca v+sign*d albo false, gdy wynik nie mieści się w int.
+func shift(v, d, sign int) (int, bool) {
+	if sign < 0 {
+		if d == math.MinInt {
+			return 0, false
+		}
+		d = -d
+	}
+	if (d > 0 && v > math.MaxInt-d) || (d < 0 && v < math.MinInt-d) {
+		return 0, false
+	}
+	return v + d, true
+}
+
+// readCells odczytuje współrzędne kulek, pomijając puste linie, i zwraca je
+// wraz z mapą przypisującą każdej kulce jej pozycję na liście oraz liczbą
+// wymiarów. Przy dims = 0 liczbę wymiarów wyznacza pierwsza linia.
+func readCells(r io.Reader, dims int) ([]cell, map[cell]int, int, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 1024), 1024*1024)
+
+	var cells []cell
+	index := make(map[cell]int)
+	line := 0
+	for scanner.Scan() {
+		line++
+		raw := strings.TrimSpace(scanner.Text())
+		if raw == "" {
+			continue
+		}
+
+		fields := strings.Split(raw, ",")
+		if dims == 0 {
+			dims = len(fields)
+			if dims < 2 || dims > MaxDims {
+				return nil, nil, 0, fmt.Errorf("line %d: %w: %d coordinates", line, ErrInvalidDimensions, dims)
+			}
+		}
+		if len(fields) != dims {
+			return nil, nil, 0, fmt.Errorf("line %d: %w: %q has %d coordinates, want %d", line, ErrInvalidCoordinate, raw, len(fields), dims)
+		}
+
+		var c cell
+		for a, field := range fields {
+			v, err := strconv.Atoi(strings.TrimSpace(field))
+			if err != nil {
+				return nil, nil, 0, fmt.Errorf("line %d: %w: %q", line, ErrInvalidCoordinate, raw)
+			}
+			c[a] = v
+		}
+		if _, dup := index[c]; dup {
+			return nil, nil, 0, fmt.Errorf("line %d: %w: %s", line, ErrDuplicateCell, raw)
+		}
+		index[c] = len(cells)
+		cells = append(cells, c)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, nil, 0, err
+	}
+	if len(cells) == 0 {
+		return nil, nil, 0, ErrNoGrid
+	}
+	return cells, index, dims, nil
+}
@@ -0,0 +1,20 @@
+package day5
+
+import (
+	"fmt"
+	"io"
+
+	"adventofcode2025/day1/src/aoc"
+)
+
+func init() {
+	aoc.Register(5, aoc.Parts{2: solvePart2})
+}
+
+func solvePart2(r io.Reader) (string, error) {
+	result, err := Compute(r)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d", result.TotalFreshIDs), nil
+}
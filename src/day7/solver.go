@@ -0,0 +1,19 @@
+package day7
+
+import (
+	"io"
+
+	"adventofcode2025/day1/src/aoc"
+)
+
+func init() {
+	aoc.Register(7, aoc.Parts{2: solvePart2})
+}
+
+func solvePart2(r io.Reader) (string, error) {
+	result, err := Compute(r)
+	if err != nil {
+		return "", err
+	}
+	return result.Timelines.String(), nil
+}
@@ -0,0 +1,89 @@
+package aoc_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"adventofcode2025/day1/src/aoc"
+	_ "adventofcode2025/day1/src/day1"
+	_ "adventofcode2025/day1/src/day10"
+	_ "adventofcode2025/day1/src/day11"
+	_ "adventofcode2025/day1/src/day12"
+	_ "adventofcode2025/day1/src/day2"
+	_ "adventofcode2025/day1/src/day3"
+	_ "adventofcode2025/day1/src/day4"
+	_ "adventofcode2025/day1/src/day5"
+	_ "adventofcode2025/day1/src/day6"
+	_ "adventofcode2025/day1/src/day7"
+	_ "adventofcode2025/day1/src/day8"
+	_ "adventofcode2025/day1/src/day9"
+)
+
+func TestAllDaysRegistered(t *testing.T) {
+	days := aoc.Days()
+	if len(days) != 12 {
+		t.Fatalf("got %d registered days, want 12: %v", len(days), days)
+	}
+	for i, day := range days {
+		if day != i+1 {
+			t.Fatalf("days[%d]=%d, want %d", i, day, i+1)
+		}
+	}
+}
+
+func TestRunSampleInputs(t *testing.T) {
+	cases := []struct {
+		day  int
+		part int
+		file string
+		want string
+	}{
+		{1, 1, "input1test.txt", "3"},
+		{1, 2, "input1test.txt", "6"},
+		{2, 1, "input2test.txt", "1227775554"},
+		{2, 2, "input2test.txt", "4174379265"},
+		{3, 1, "input3test.txt", "357"},
+		{3, 2, "input3test.txt", "3121910778619"},
+		{4, 1, "input4test.txt", "13"},
+		{4, 0, "input4test.txt", "43"},
+		{7, 2, "input7test.txt", "40"},
+		{12, 0, "input12test.txt", "2"},
+	}
+	for _, tt := range cases {
+		data, err := os.ReadFile(filepath.Join("..", "..", tt.file))
+		if err != nil {
+			t.Fatalf("ReadFile(%s): %v", tt.file, err)
+		}
+		got, err := aoc.Run(tt.day, tt.part, bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("Run(day %d, part %d) error: %v", tt.day, tt.part, err)
+		}
+		if got != tt.want {
+			t.Fatalf("Run(day %d, part %d)=%s, want %s", tt.day, tt.part, got, tt.want)
+		}
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	if _, err := aoc.Run(42, 1, bytes.NewBufferString("")); !errors.Is(err, aoc.ErrUnknownDay) {
+		t.Fatalf("unknown day: got %v, want ErrUnknownDay", err)
+	}
+	if _, err := aoc.Run(12, 2, bytes.NewBufferString("")); !errors.Is(err, aoc.ErrUnknownPart) {
+		t.Fatalf("unknown part: got %v, want ErrUnknownPart", err)
+	}
+}
+
+func TestReportErrors(t *testing.T) {
+	if err := aoc.Report(42, 1, "table", bytes.NewBufferString(""), &bytes.Buffer{}); !errors.Is(err, aoc.ErrUnknownDay) {
+		t.Fatalf("unknown day: got %v, want ErrUnknownDay", err)
+	}
+	if err := aoc.Report(12, 0, "table", bytes.NewBufferString(""), &bytes.Buffer{}); !errors.Is(err, aoc.ErrNoReport) {
+		t.Fatalf("no report: got %v, want ErrNoReport", err)
+	}
+	if err := aoc.Report(2, 0, "xml", bytes.NewBufferString("11-22"), &bytes.Buffer{}); !errors.Is(err, aoc.ErrUnknownFormat) {
+		t.Fatalf("unknown format: got %v, want ErrUnknownFormat", err)
+	}
+}
@@ -0,0 +1,103 @@
+package day1_test
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+
+	"adventofcode2025/day1/src/day1"
+)
+
+func TestParseBigToken(t *testing.T) {
+	token := "R99999999999999999999999"
+	if _, _, err := day1.ParseToken(token); err == nil {
+		t.Fatalf("ParseToken(%s) expected error", token)
+	}
+
+	dir, mag, err := day1.ParseBigToken(token)
+	if err != nil {
+		t.Fatalf("ParseBigToken(%s) unexpected error: %v", token, err)
+	}
+	if dir != 'R' || mag.String() != token[1:] {
+		t.Fatalf("ParseBigToken(%s) got (%c,%s)", token, dir, mag)
+	}
+
+	for _, bad := range []string{"R", "X1", "L0", "L+5", "L1_000"} {
+		if _, _, err := day1.ParseBigToken(bad); err == nil {
+			t.Fatalf("ParseBigToken(%s) expected error", bad)
+		}
+	}
+}
+
+func TestComputeHugeMagnitude(t *testing.T) {
+	res, err := day1.Compute(bytes.NewBufferString("R99999999999999999999999 L1"))
+	if err != nil {
+		t.Fatalf("Compute error: %v", err)
+	}
+	// 50 + ...99 lands on 49 after passing 0 once per 100 clicks, then L1 -> 48.
+	if res.Position != 48 {
+		t.Fatalf("Position=%d, want 48", res.Position)
+	}
+	if res.BigZeroCrossings == nil || res.BigZeroCrossings.String() != "1000000000000000000000" {
+		t.Fatalf("BigZeroCrossings=%v, want 10^21", res.BigZeroCrossings)
+	}
+	if res.ZeroCrossings != math.MaxInt {
+		t.Fatalf("ZeroCrossings=%d, want math.MaxInt on overflow", res.ZeroCrossings)
+	}
+	if res.Targets[0].BigCrossings == nil || res.Targets[0].BigCrossings.Cmp(res.BigZeroCrossings) != 0 {
+		t.Fatalf("target BigCrossings=%v, want %v", res.Targets[0].BigCrossings, res.BigZeroCrossings)
+	}
+}
+
+func TestComputeLargeMagnitudeWithinInt(t *testing.T) {
+	// Magnitudes that fit in an int still report exact int totals.
+	res, err := day1.Compute(bytes.NewBufferString("R9223372036854775800"))
+	if err != nil {
+		t.Fatalf("Compute error: %v", err)
+	}
+	if res.BigZeroCrossings != nil || res.ZeroCrossings != 92233720368547758 {
+		t.Fatalf("got (%d,%v), want (92233720368547758,nil)", res.ZeroCrossings, res.BigZeroCrossings)
+	}
+	// 50 + 9223372036854775800 ends on 50 since the magnitude is a multiple of 100.
+	if res.Position != 50 {
+		t.Fatalf("Position=%d, want 50", res.Position)
+	}
+
+	res, err = day1.Compute(bytes.NewBufferString(fmt.Sprintf("R%d", math.MaxInt)))
+	if err != nil {
+		t.Fatalf("Compute error: %v", err)
+	}
+	if res.Position != 57 || res.ZeroCrossings != 92233720368547758 {
+		t.Fatalf("R MaxInt: got position %d crossings %d, want 57 and 92233720368547758", res.Position, res.ZeroCrossings)
+	}
+
+	res, err = day1.Compute(bytes.NewBufferString(fmt.Sprintf("L%d", math.MaxInt)))
+	if err != nil {
+		t.Fatalf("Compute error: %v", err)
+	}
+	if res.Position != 43 || res.ZeroCrossings != 92233720368547758 {
+		t.Fatalf("L MaxInt: got position %d crossings %d, want 43 and 92233720368547758", res.Position, res.ZeroCrossings)
+	}
+}
+
+func TestRotateBigMatchesRotate(t *testing.T) {
+	small, _ := day1.NewDial(day1.DefaultOptions())
+	large, _ := day1.NewDial(day1.DefaultOptions())
+	for _, mag := range []int{1, 49, 50, 100, 151, 999} {
+		for _, dir := range []rune{'L', 'R'} {
+			pos, hits, err := small.Rotate(dir, mag)
+			if err != nil {
+				t.Fatalf("Rotate error: %v", err)
+			}
+			bigPos, bigHits, err := large.RotateBig(dir, big.NewInt(int64(mag)))
+			if err != nil {
+				t.Fatalf("RotateBig error: %v", err)
+			}
+			if pos != bigPos || bigHits.Cmp(big.NewInt(int64(hits))) != 0 {
+				t.Fatalf("%c%d: Rotate=(%d,%d) RotateBig=(%d,%s)", dir, mag, pos, hits, bigPos, bigHits)
+			}
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package day1_test
+
+import (
+	"bytes"
+	"testing"
+
+	"adventofcode2025/day1/src/day1"
+)
+
+func TestDialRotate(t *testing.T) {
+	d, err := day1.NewDial(day1.DefaultOptions())
+	if err != nil {
+		t.Fatalf("NewDial error: %v", err)
+	}
+
+	steps := []struct {
+		dir  rune
+		mag  int
+		pos  int
+		hits int
+	}{
+		{'L', 68, 82, 1},
+		{'L', 30, 52, 0},
+		{'R', 48, 0, 1},
+		{'R', 250, 50, 2},
+	}
+	for i, s := range steps {
+		pos, hits, err := d.Rotate(s.dir, s.mag)
+		if err != nil {
+			t.Fatalf("step %d: Rotate error: %v", i+1, err)
+		}
+		if pos != s.pos || hits != s.hits {
+			t.Fatalf("step %d: Rotate(%c,%d)=(%d,%d), want (%d,%d)", i+1, s.dir, s.mag, pos, hits, s.pos, s.hits)
+		}
+	}
+
+	res := d.Result()
+	if res.Position != 50 || res.ZeroStops != 1 || res.ZeroCrossings != 4 {
+		t.Fatalf("Result got (%d,%d,%d), want (50,1,4)", res.Position, res.ZeroStops, res.ZeroCrossings)
+	}
+}
+
+func TestDialRotateRejectsInvalidInput(t *testing.T) {
+	d, err := day1.NewDial(day1.DefaultOptions())
+	if err != nil {
+		t.Fatalf("NewDial error: %v", err)
+	}
+	if _, _, err := d.Rotate('X', 5); err == nil {
+		t.Fatalf("expected error for invalid direction")
+	}
+	if _, _, err := d.Rotate('L', 0); err == nil {
+		t.Fatalf("expected error for zero magnitude")
+	}
+	if d.Position() != day1.InitialValue || d.Steps() != 0 {
+		t.Fatalf("rejected rotations must not move the dial")
+	}
+}
+
+func TestComputeWithOnRotate(t *testing.T) {
+	var events []day1.Event
+	opts := day1.DefaultOptions()
+	opts.OnRotate = func(e day1.Event) { events = append(events, e) }
+
+	if _, err := day1.ComputeWith(bytes.NewBufferString("R50 L150 R7"), opts); err != nil {
+		t.Fatalf("ComputeWith error: %v", err)
+	}
+
+	want := []day1.Event{
+		{Step: 1, Dir: 'R', Magnitude: 50, From: 50, To: 0, Crossings: 1, Stopped: true},
+		{Step: 2, Dir: 'L', Magnitude: 150, From: 0, To: 50, Crossings: 1, Stopped: false},
+		{Step: 3, Dir: 'R', Magnitude: 7, From: 50, To: 57, Crossings: 0, Stopped: false},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("got %d events, want %d", len(events), len(want))
+	}
+	for i, w := range want {
+		if events[i] != w {
+			t.Fatalf("event %d: got %+v, want %+v", i, events[i], w)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package day1_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"adventofcode2025/day1/src/day1"
+)
+
+func TestExtendedGrammarMatchesPlainTokens(t *testing.T) {
+	cases := []struct {
+		extended string
+		plain    string
+	}{
+		{"# sample\nL68, L30,R48 # trailing comment\nL5 R60 L55 L1 L99 R14 L82", "L68 L30 R48 L5 R60 L55 L1 L99 R14 L82"},
+		{"(R10 L3)x3", "R10 L3 R10 L3 R10 L3"},
+		{"((R1)x2 L1)x3 R50", "R1 R1 L1 R1 R1 L1 R1 R1 L1 R50"},
+		{"(R7 L2)", "R7 L2"},
+		{"()x4 L9", "L9"},
+		{"(L150,R250)x2#no space before comment", "L150 R250 L150 R250"},
+	}
+	for _, tt := range cases {
+		t.Run(tt.extended, func(t *testing.T) {
+			want, err := day1.Compute(bytes.NewBufferString(tt.plain))
+			if err != nil {
+				t.Fatalf("Compute(%q) error: %v", tt.plain, err)
+			}
+			got, err := day1.Compute(bytes.NewBufferString(tt.extended))
+			if err != nil {
+				t.Fatalf("Compute(%q) error: %v", tt.extended, err)
+			}
+			if got.Position != want.Position || got.ZeroStops != want.ZeroStops || got.ZeroCrossings != want.ZeroCrossings {
+				t.Fatalf("got (%d,%d,%d), want (%d,%d,%d)", got.Position, got.ZeroStops, got.ZeroCrossings, want.Position, want.ZeroStops, want.ZeroCrossings)
+			}
+		})
+	}
+}
+
+func TestExtendedGrammarErrors(t *testing.T) {
+	cases := []struct {
+		input string
+		want  error
+		msg   string
+	}{
+		{"R10\n  L5 X7", day1.ErrInvalidDirection, "line 2 col 6 (X7)"},
+		{"R1 # ok\n(L2 (R3)x2", day1.ErrUnbalancedGroup, "line 2 col 1 (()"},
+		{"R1 )", day1.ErrUnbalancedGroup, "line 1 col 4 ())"},
+		{"(R1)x0", day1.ErrInvalidRepeat, "line 1 col 4 ()x0)"},
+		{"(R1)xa", day1.ErrInvalidRepeat, "line 1 col 4 ()xa)"},
+		{"(R1)x", day1.ErrInvalidRepeat, "line 1 col 4 ()x)"},
+		{"(R10 L3) x3", day1.ErrInvalidDirection, "line 1 col 10 (x3)"},
+		{"L1,,Lxx", day1.ErrInvalidMagnitude, "line 1 col 5 (Lxx)"},
+		{"# only a comment\n(  )x3", day1.ErrNoInstructions, ""},
+	}
+	for _, tt := range cases {
+		t.Run(tt.input, func(t *testing.T) {
+			_, err := day1.Compute(bytes.NewBufferString(tt.input))
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Compute(%q) error=%v, want %v", tt.input, err, tt.want)
+			}
+			if !strings.Contains(err.Error(), tt.msg) {
+				t.Fatalf("Compute(%q) error=%q, want it to contain %q", tt.input, err, tt.msg)
+			}
+		})
+	}
+}
+
+func TestExtendedGrammarRejectsHugeRepeats(t *testing.T) {
+	cases := []struct {
+		input string
+		want  error
+	}{
+		{"(R1)x9223372036854775807", day1.ErrInvalidRepeat},
+		{"(((R1)x1000)x1000)x1000", day1.ErrInvalidRepeat},
+		{"((R1 L1)x4096 R2)x8192", day1.ErrInvalidRepeat},
+		// Empty groups do nothing, whatever their count.
+		{"()x9223372036854775807", day1.ErrNoInstructions},
+		{"R5 ()x9223372036854775807", nil},
+		{"(()x9999999999)x9999999999 R5", nil},
+	}
+	for _, tt := range cases {
+		done := make(chan error, 1)
+		go func() {
+			_, err := day1.Compute(bytes.NewBufferString(tt.input))
+			done <- err
+		}()
+		select {
+		case err := <-done:
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Compute(%q) error=%v, want %v", tt.input, err, tt.want)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Compute(%q) did not return", tt.input)
+		}
+	}
+
+	// Groups within the limit still run.
+	res, err := day1.Compute(bytes.NewBufferString("((R1)x1000)x1000"))
+	if err != nil {
+		t.Fatalf("Compute error: %v", err)
+	}
+	if res.ZeroCrossings != 10000 || res.Position != 50 {
+		t.Fatalf("got (%d,%d), want position 50 and 10000 crossings", res.Position, res.ZeroCrossings)
+	}
+}
+
+func TestComputeParallelAcceptsExtendedGrammar(t *testing.T) {
+	data := randomInstructions(3, 600000)
+	data = append([]byte("# generated\n(R10 L3)x50\n"), data...)
+
+	want, err := day1.ComputeWith(bytes.NewReader(data), day1.DefaultOptions())
+	if err != nil {
+		t.Fatalf("ComputeWith error: %v", err)
+	}
+	got, err := day1.ComputeParallel(bytes.NewReader(data), int64(len(data)), day1.DefaultOptions(), 4)
+	if err != nil {
+		t.Fatalf("ComputeParallel error: %v", err)
+	}
+	if got.Position != want.Position || got.ZeroStops != want.ZeroStops || got.ZeroCrossings != want.ZeroCrossings {
+		t.Fatalf("got (%d,%d,%d), want (%d,%d,%d)", got.Position, got.ZeroStops, got.ZeroCrossings, want.Position, want.ZeroStops, want.ZeroCrossings)
+	}
+}
@@ -0,0 +1,74 @@
+package day1_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"adventofcode2025/day1/src/day1"
+)
+
+func TestHistogramMatchesPerTargetCrossings(t *testing.T) {
+	input := "L68 L30 R48 L5 R60 L55 L1 L99 R14 L82 R1000 L250 R7"
+	opts := day1.DefaultOptions()
+	opts.Histogram = true
+	res, err := day1.ComputeWith(bytes.NewBufferString(input), opts)
+	if err != nil {
+		t.Fatalf("ComputeWith error: %v", err)
+	}
+	if len(res.Histogram) != 100 {
+		t.Fatalf("got histogram of %d positions, want 100", len(res.Histogram))
+	}
+
+	total := 0
+	for target, got := range res.Histogram {
+		single, err := day1.ComputeWith(bytes.NewBufferString(input), day1.Options{DialSize: 100, Start: 50, Targets: []int{target}})
+		if err != nil {
+			t.Fatalf("ComputeWith error: %v", err)
+		}
+		if got != single.ZeroCrossings {
+			t.Fatalf("Histogram[%d]=%d, want %d", target, got, single.ZeroCrossings)
+		}
+		total += got
+	}
+	if total != 68+30+48+5+60+55+1+99+14+82+1000+250+7 {
+		t.Fatalf("histogram total=%d does not match the clicks applied", total)
+	}
+	if res.Histogram[0] != res.ZeroCrossings {
+		t.Fatalf("Histogram[0]=%d, want %d", res.Histogram[0], res.ZeroCrossings)
+	}
+}
+
+func TestHistogramSmallDial(t *testing.T) {
+	opts := day1.Options{DialSize: 5, Start: 3, Histogram: true}
+	// R4 visits 4,0,1,2; L7 visits 1,0,4,3,2,1,0.
+	res, err := day1.ComputeWith(bytes.NewBufferString("R4 L7"), opts)
+	if err != nil {
+		t.Fatalf("ComputeWith error: %v", err)
+	}
+	want := []int{3, 3, 2, 1, 2}
+	for i, w := range want {
+		if res.Histogram[i] != w {
+			t.Fatalf("Histogram=%v, want %v", res.Histogram, want)
+		}
+	}
+}
+
+func TestHistogramOnlyWhenRequested(t *testing.T) {
+	res, err := day1.Compute(bytes.NewBufferString("R10"))
+	if err != nil {
+		t.Fatalf("Compute error: %v", err)
+	}
+	if res.Histogram != nil {
+		t.Fatalf("Histogram=%v, want nil", res.Histogram)
+	}
+}
+
+func TestHistogramOverflow(t *testing.T) {
+	opts := day1.DefaultOptions()
+	opts.Histogram = true
+	_, err := day1.ComputeWith(bytes.NewBufferString("R99999999999999999999999"), opts)
+	if !errors.Is(err, day1.ErrHistogramOverflow) {
+		t.Fatalf("got %v, want ErrHistogramOverflow", err)
+	}
+}
@@ -0,0 +1,116 @@
+package day1_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"adventofcode2025/day1/src/day1"
+)
+
+func TestParseWheelToken(t *testing.T) {
+	cases := []struct {
+		in    string
+		wheel int
+		dir   rune
+		mag   int
+	}{
+		{"L15", 1, 'L', 15},
+		{"2L15", 2, 'L', 15},
+		{"12R3", 12, 'R', 3},
+	}
+	for _, tt := range cases {
+		wheel, dir, mag, err := day1.ParseWheelToken(tt.in)
+		if err != nil {
+			t.Fatalf("ParseWheelToken(%s) unexpected error: %v", tt.in, err)
+		}
+		if wheel != tt.wheel || dir != tt.dir || mag != tt.mag {
+			t.Fatalf("ParseWheelToken(%s) got (%d,%c,%d), want (%d,%c,%d)", tt.in, wheel, dir, mag, tt.wheel, tt.dir, tt.mag)
+		}
+	}
+}
+
+func TestComputeOdometerCarries(t *testing.T) {
+	// R50: wheel 1 wraps to 0 and carries into wheel 2 (51).
+	// L1: wheel 1 leaves 0 to 99 and borrows from wheel 2 (50).
+	// 2L15: wheel 2 alone moves to 35.
+	// R250: wheel 1 wraps three times (49), wheel 2 moves to 38.
+	res, err := day1.ComputeOdometer(bytes.NewBufferString("R50 L1 2L15 R250"), 2, day1.DefaultOptions())
+	if err != nil {
+		t.Fatalf("ComputeOdometer error: %v", err)
+	}
+	if len(res.Wheels) != 2 {
+		t.Fatalf("got %d wheels, want 2", len(res.Wheels))
+	}
+
+	want := []struct {
+		pos, stops, crossings int
+	}{
+		{49, 1, 4},
+		{38, 0, 0},
+	}
+	for i, w := range want {
+		got := res.Wheels[i]
+		if got.Position != w.pos || got.ZeroStops != w.stops || got.ZeroCrossings != w.crossings {
+			t.Fatalf("wheel %d got (%d,%d,%d), want (%d,%d,%d)", i+1, got.Position, got.ZeroStops, got.ZeroCrossings, w.pos, w.stops, w.crossings)
+		}
+	}
+	if res.Overflow != 0 {
+		t.Fatalf("Overflow=%d, want 0", res.Overflow)
+	}
+}
+
+func TestComputeOdometerOverflow(t *testing.T) {
+	res, err := day1.ComputeOdometer(bytes.NewBufferString("R150 L300"), 1, day1.DefaultOptions())
+	if err != nil {
+		t.Fatalf("ComputeOdometer error: %v", err)
+	}
+	// R150 carries twice out of the wheel, L300 from 0 borrows three times.
+	if res.Overflow != -1 {
+		t.Fatalf("Overflow=%d, want -1", res.Overflow)
+	}
+	if res.Wheels[0].Position != 0 {
+		t.Fatalf("Position=%d, want 0", res.Wheels[0].Position)
+	}
+}
+
+func TestComputeOdometerNearMaxInt(t *testing.T) {
+	// Wheel 1 ends on 57 and carries 92233720368547758 into wheel 2, which
+	// ends on 8 and carries 922337203685478 into wheel 3 (28, carry
+	// 9223372036855 out of the odometer).
+	res, err := day1.ComputeOdometer(bytes.NewBufferString(fmt.Sprintf("R%d", math.MaxInt)), 3, day1.DefaultOptions())
+	if err != nil {
+		t.Fatalf("ComputeOdometer error: %v", err)
+	}
+	for i, want := range []int{57, 8, 28} {
+		if res.Wheels[i].Position != want {
+			t.Fatalf("wheel %d Position=%d, want %d", i+1, res.Wheels[i].Position, want)
+		}
+	}
+	if res.Overflow != 9223372036855 {
+		t.Fatalf("Overflow=%d, want 9223372036855", res.Overflow)
+	}
+}
+
+func TestComputeOdometerErrors(t *testing.T) {
+	cases := []struct {
+		input string
+		want  error
+	}{
+		{"3L5", day1.ErrInvalidWheel},
+		{"0L5", day1.ErrInvalidWheel},
+		{"2X5", day1.ErrInvalidDirection},
+		{"  ", day1.ErrNoInstructions},
+	}
+	for _, tt := range cases {
+		_, err := day1.ComputeOdometer(bytes.NewBufferString(tt.input), 2, day1.DefaultOptions())
+		if !errors.Is(err, tt.want) {
+			t.Fatalf("ComputeOdometer(%q) error=%v, want %v", tt.input, err, tt.want)
+		}
+	}
+	if _, err := day1.NewOdometer(0, day1.DefaultOptions()); err == nil {
+		t.Fatalf("expected error for zero wheels")
+	}
+}
@@ -0,0 +1,76 @@
+package day1_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"adventofcode2025/day1/src/day1"
+)
+
+func TestComputeWithDefaultsMatchesCompute(t *testing.T) {
+	input := "L68 L30 R48 L5 R60 L55 L1 L99 R14 L82"
+	res, err := day1.ComputeWith(bytes.NewBufferString(input), day1.DefaultOptions())
+	if err != nil {
+		t.Fatalf("ComputeWith error: %v", err)
+	}
+	if res.Position != 32 || res.ZeroStops != 3 || res.ZeroCrossings != 6 {
+		t.Fatalf("ComputeWith got (%d,%d,%d), want (32,3,6)", res.Position, res.ZeroStops, res.ZeroCrossings)
+	}
+}
+
+func TestComputeWithSinglePositionDial(t *testing.T) {
+	opts := day1.Options{DialSize: 1}
+	res, err := day1.ComputeWith(bytes.NewBufferString("L3 R4"), opts)
+	if err != nil {
+		t.Fatalf("ComputeWith error: %v", err)
+	}
+	// Every click lands on the only position.
+	if res.Position != 0 || res.ZeroStops != 2 || res.ZeroCrossings != 7 {
+		t.Fatalf("ComputeWith got (%d,%d,%d), want (0,2,7)", res.Position, res.ZeroStops, res.ZeroCrossings)
+	}
+}
+
+func TestComputeWithMultipleTargets(t *testing.T) {
+	opts := day1.Options{DialSize: 10, Start: 5, Targets: []int{0, 7}}
+	res, err := day1.ComputeWith(bytes.NewBufferString("R2 R15 L3"), opts)
+	if err != nil {
+		t.Fatalf("ComputeWith error: %v", err)
+	}
+	// 5 -R2-> 7 -R15-> 2 (via 0, 7, 0) -L3-> 9 (via 0)
+	want := []day1.TargetCount{
+		{Position: 0, Stops: 0, Crossings: 3},
+		{Position: 7, Stops: 1, Crossings: 2},
+	}
+	if len(res.Targets) != len(want) {
+		t.Fatalf("got %d targets, want %d", len(res.Targets), len(want))
+	}
+	for i, w := range want {
+		if res.Targets[i] != w {
+			t.Fatalf("target %d: got %+v, want %+v", i, res.Targets[i], w)
+		}
+	}
+	if res.Position != 9 || res.ZeroStops != 1 || res.ZeroCrossings != 5 {
+		t.Fatalf("ComputeWith got (%d,%d,%d), want (9,1,5)", res.Position, res.ZeroStops, res.ZeroCrossings)
+	}
+}
+
+func TestComputeWithInvalidOptions(t *testing.T) {
+	cases := []struct {
+		name string
+		opts day1.Options
+	}{
+		{"ZeroSize", day1.Options{}},
+		{"StartOutside", day1.Options{DialSize: 10, Start: 10}},
+		{"TargetOutside", day1.Options{DialSize: 10, Targets: []int{-1}}},
+		{"DuplicateTarget", day1.Options{DialSize: 10, Targets: []int{3, 3}}},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := day1.ComputeWith(bytes.NewBufferString("R1"), tt.opts)
+			if !errors.Is(err, day1.ErrInvalidOptions) {
+				t.Fatalf("got %v, want ErrInvalidOptions", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,250 @@
+package day1_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"adventofcode2025/day1/src/day1"
+)
+
+// randomInstructions builds an input large enough to be split into several
+// chunks by ComputeParallel.
+func randomInstructions(seed int64, tokens int) []byte {
+	rng := rand.New(rand.NewSource(seed))
+	var buf bytes.Buffer
+	for i := 0; i < tokens; i++ {
+		dir := 'L'
+		if rng.Intn(2) == 0 {
+			dir = 'R'
+		}
+		fmt.Fprintf(&buf, "%c%d", dir, 1+rng.Intn(450))
+		if rng.Intn(5) == 0 {
+			buf.WriteByte('\n')
+		} else {
+			buf.WriteByte(' ')
+		}
+	}
+	return buf.Bytes()
+}
+
+func TestComputeParallelMatchesSequential(t *testing.T) {
+	data := randomInstructions(1, 600000)
+	optsList := []day1.Options{
+		day1.DefaultOptions(),
+		{DialSize: 100, Start: 13, Targets: []int{0, 37, 99}, Histogram: true},
+		{DialSize: 7, Start: 6, Targets: []int{3}},
+	}
+	for _, opts := range optsList {
+		want, err := day1.ComputeWith(bytes.NewReader(data), opts)
+		if err != nil {
+			t.Fatalf("ComputeWith error: %v", err)
+		}
+		for _, workers := range []int{1, 3, 8} {
+			got, err := day1.ComputeParallel(bytes.NewReader(data), int64(len(data)), opts, workers)
+			if err != nil {
+				t.Fatalf("ComputeParallel error: %v", err)
+			}
+			if got.Position != want.Position || got.ZeroStops != want.ZeroStops || got.ZeroCrossings != want.ZeroCrossings {
+				t.Fatalf("workers=%d: got (%d,%d,%d), want (%d,%d,%d)", workers,
+					got.Position, got.ZeroStops, got.ZeroCrossings, want.Position, want.ZeroStops, want.ZeroCrossings)
+			}
+			for i := range want.Targets {
+				if got.Targets[i] != want.Targets[i] {
+					t.Fatalf("workers=%d: target %d got %+v, want %+v", workers, i, got.Targets[i], want.Targets[i])
+				}
+			}
+			if len(got.Histogram) != len(want.Histogram) {
+				t.Fatalf("workers=%d: histogram length %d, want %d", workers, len(got.Histogram), len(want.Histogram))
+			}
+			for i := range want.Histogram {
+				if got.Histogram[i] != want.Histogram[i] {
+					t.Fatalf("workers=%d: Histogram[%d]=%d, want %d", workers, i, got.Histogram[i], want.Histogram[i])
+				}
+			}
+		}
+	}
+}
+
+// countingReader counts the bytes read through it.
+type countingReader struct {
+	r    io.ReaderAt
+	read atomic.Int64
+}
+
+func (c *countingReader) ReadAt(p []byte, off int64) (int, error) {
+	n, err := c.r.ReadAt(p, off)
+	c.read.Add(int64(n))
+	return n, err
+}
+
+func TestComputeParallelReportsGlobalPosition(t *testing.T) {
+	data := randomInstructions(2, 600000)
+	data = append(data, []byte(" R5 X9")...)
+
+	_, want := day1.ComputeWith(bytes.NewReader(data), day1.DefaultOptions())
+	cr := &countingReader{r: bytes.NewReader(data)}
+	_, got := day1.ComputeParallel(cr, int64(len(data)), day1.DefaultOptions(), 4)
+	if want == nil || got == nil || got.Error() != want.Error() {
+		t.Fatalf("ComputeParallel error %v, want %v", got, want)
+	}
+	if !errors.Is(got, day1.ErrInvalidDirection) {
+		t.Fatalf("got %v, want ErrInvalidDirection", got)
+	}
+	if read := cr.read.Load(); read > int64(len(data))*11/10 {
+		t.Fatalf("read %d bytes of a %d byte input, want a single pass", read, len(data))
+	}
+}
+
+// groupedInstructions builds an input using the whole grammar, with groups
+// and comments long enough to straddle chunk bounds.
+func groupedInstructions(seed int64, items int) []byte {
+	rng := rand.New(rand.NewSource(seed))
+	var buf bytes.Buffer
+	depth := 0
+	for i := 0; i < items; i++ {
+		switch k := rng.Intn(40); {
+		case k == 0:
+			buf.WriteString("# a comment (R1 with L2 tokens) " + strings.Repeat("x", rng.Intn(400)) + "\n")
+		case k == 1 && depth < 3:
+			buf.WriteString("(")
+			depth++
+		case k <= 4 && depth > 0:
+			fmt.Fprintf(&buf, ")x%d\n", 1+rng.Intn(3))
+			depth--
+		default:
+			dir := 'L'
+			if rng.Intn(2) == 0 {
+				dir = 'R'
+			}
+			fmt.Fprintf(&buf, "%c%d,", dir, 1+rng.Intn(450))
+			if rng.Intn(5) == 0 {
+				buf.WriteByte('\n')
+			}
+		}
+	}
+	buf.WriteString(strings.Repeat(")", depth))
+	return buf.Bytes()
+}
+
+func TestComputeParallelExtendedGrammar(t *testing.T) {
+	data := groupedInstructions(3, 400000)
+	opts := day1.Options{DialSize: 100, Start: 50, Targets: []int{0, 42}, Histogram: true}
+	want, err := day1.ComputeWith(bytes.NewReader(data), opts)
+	if err != nil {
+		t.Fatalf("ComputeWith error: %v", err)
+	}
+	for _, workers := range []int{1, 3, 8} {
+		got, err := day1.ComputeParallel(bytes.NewReader(data), int64(len(data)), opts, workers)
+		if err != nil {
+			t.Fatalf("workers=%d: ComputeParallel error: %v", workers, err)
+		}
+		if got.Position != want.Position || got.ZeroStops != want.ZeroStops || got.ZeroCrossings != want.ZeroCrossings {
+			t.Fatalf("workers=%d: got (%d,%d,%d), want (%d,%d,%d)", workers,
+				got.Position, got.ZeroStops, got.ZeroCrossings, want.Position, want.ZeroStops, want.ZeroCrossings)
+		}
+		for i := range want.Histogram {
+			if got.Histogram[i] != want.Histogram[i] {
+				t.Fatalf("workers=%d: Histogram[%d]=%d, want %d", workers, i, got.Histogram[i], want.Histogram[i])
+			}
+		}
+	}
+
+	// An error after a group straddling chunks is still located globally.
+	bad := append(append([]byte(nil), data...), []byte("\n(R1\n  L2 Q7)")...)
+	_, want2 := day1.ComputeWith(bytes.NewReader(bad), opts)
+	_, got2 := day1.ComputeParallel(bytes.NewReader(bad), int64(len(bad)), opts, 4)
+	if want2 == nil || got2 == nil || got2.Error() != want2.Error() {
+		t.Fatalf("ComputeParallel error %v, want %v", got2, want2)
+	}
+
+	// So is a group left open at the end of the input.
+	open := append([]byte("R1 (L2\n"), data...)
+	_, want3 := day1.ComputeWith(bytes.NewReader(open), opts)
+	_, got3 := day1.ComputeParallel(bytes.NewReader(open), int64(len(open)), opts, 4)
+	if !errors.Is(got3, day1.ErrUnbalancedGroup) || got3.Error() != want3.Error() {
+		t.Fatalf("ComputeParallel error %v, want %v", got3, want3)
+	}
+}
+
+func TestComputeParallelLargeDial(t *testing.T) {
+	data := randomInstructions(4, 600000)
+	for _, opts := range []day1.Options{
+		{DialSize: 5000, Start: 4321, Targets: []int{0, 17, 4999}, Histogram: true},
+		{DialSize: 1 << 40, Start: 1 << 39, Targets: []int{1<<39 + 100, 1<<39 - 100}},
+	} {
+		want, err := day1.ComputeWith(bytes.NewReader(data), opts)
+		if err != nil {
+			t.Fatalf("ComputeWith error: %v", err)
+		}
+		for _, workers := range []int{1, 4} {
+			got, err := day1.ComputeParallel(bytes.NewReader(data), int64(len(data)), opts, workers)
+			if err != nil {
+				t.Fatalf("ComputeParallel error: %v", err)
+			}
+			if got.Position != want.Position || got.ZeroStops != want.ZeroStops || got.ZeroCrossings != want.ZeroCrossings {
+				t.Fatalf("dial %d workers=%d: got (%d,%d,%d), want (%d,%d,%d)", opts.DialSize, workers,
+					got.Position, got.ZeroStops, got.ZeroCrossings, want.Position, want.ZeroStops, want.ZeroCrossings)
+			}
+			for i := range want.Targets {
+				if got.Targets[i] != want.Targets[i] {
+					t.Fatalf("dial %d workers=%d: target %d got %+v, want %+v", opts.DialSize, workers, i, got.Targets[i], want.Targets[i])
+				}
+			}
+			for i := range want.Histogram {
+				if got.Histogram[i] != want.Histogram[i] {
+					t.Fatalf("dial %d workers=%d: Histogram[%d]=%d, want %d", opts.DialSize, workers, i, got.Histogram[i], want.Histogram[i])
+				}
+			}
+		}
+	}
+
+	// Without a histogram, memory does not grow with the dial.
+	input := "R5 L3 R100000"
+	opts := day1.Options{DialSize: 1 << 26}
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	if _, err := day1.ComputeParallel(strings.NewReader(input), int64(len(input)), opts, 8); err != nil {
+		t.Fatalf("ComputeParallel error: %v", err)
+	}
+	runtime.ReadMemStats(&after)
+	if alloc := after.TotalAlloc - before.TotalAlloc; alloc > 1<<24 {
+		t.Fatalf("allocated %d bytes for a dial of %d positions", alloc, opts.DialSize)
+	}
+}
+
+func TestComputeParallelSmallInputs(t *testing.T) {
+	input := "L68 L30 R48 L5 R60 L55 L1 L99 R14 L82"
+	res, err := day1.ComputeParallel(strings.NewReader(input), int64(len(input)), day1.DefaultOptions(), 0)
+	if err != nil {
+		t.Fatalf("ComputeParallel error: %v", err)
+	}
+	if res.Position != 32 || res.ZeroStops != 3 || res.ZeroCrossings != 6 {
+		t.Fatalf("got (%d,%d,%d), want (32,3,6)", res.Position, res.ZeroStops, res.ZeroCrossings)
+	}
+
+	if _, err := day1.ComputeParallel(strings.NewReader(" \n"), 2, day1.DefaultOptions(), 2); !errors.Is(err, day1.ErrNoInstructions) {
+		t.Fatalf("got %v, want ErrNoInstructions", err)
+	}
+
+	huge := "R99999999999999999999999 L1"
+	res, err = day1.ComputeParallel(strings.NewReader(huge), int64(len(huge)), day1.DefaultOptions(), 2)
+	if err != nil {
+		t.Fatalf("ComputeParallel error: %v", err)
+	}
+	if res.BigZeroCrossings == nil || res.BigZeroCrossings.String() != "1000000000000000000000" {
+		t.Fatalf("BigZeroCrossings=%v, want 10^21", res.BigZeroCrossings)
+	}
+
+	opts := day1.DefaultOptions()
+	opts.OnRotate = func(day1.Event) {}
+	if _, err := day1.ComputeParallel(strings.NewReader(input), int64(len(input)), opts, 2); !errors.Is(err, day1.ErrInvalidOptions) {
+		t.Fatalf("got %v, want ErrInvalidOptions", err)
+	}
+}
@@ -135,6 +135,32 @@ func TestZeroCrossingCounting(t *testing.T) {
 	}
 }
 
+func TestZeroStopCounting(t *testing.T) {
+	cases := []struct {
+		input     string
+		zeroStops int
+	}{
+		{"R50", 1},
+		{"R50 R50", 1},       // leaves 0 on the second rotation
+		{"L75", 0},           // passes 0 without stopping there
+		{"L150", 1},          // wraps past 0 once, then stops on it
+		{"R50 R100 L200", 3}, // full turns keep returning to 0
+		{"L68 L30 R48 L5 R60 L55 L1 L99 R14 L82", 3}, // sample
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.input, func(t *testing.T) {
+			res, err := day1.Compute(bytes.NewBufferString(tt.input))
+			if err != nil {
+				t.Fatalf("Compute(%q) error: %v", tt.input, err)
+			}
+			if res.ZeroStops != tt.zeroStops {
+				t.Fatalf("Compute(%q) zeroStops=%d, want %d", tt.input, res.ZeroStops, tt.zeroStops)
+			}
+		})
+	}
+}
+
 func BenchmarkComputeLargeSequence(b *testing.B) {
 	// Build a 10k-token alternating sequence.
 	var buf bytes.Buffer
@@ -0,0 +1,116 @@
+package day1_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"adventofcode2025/day1/src/day1"
+)
+
+func TestSynthesizeFewestClicks(t *testing.T) {
+	ins, err := day1.Synthesize(50, []int{40, 40, 90, 0, 60}, day1.FewestClicks)
+	if err != nil {
+		t.Fatalf("Synthesize error: %v", err)
+	}
+	if got, want := day1.FormatInstructions(ins), "L10 R50 R10 L40"; got != want {
+		t.Fatalf("Synthesize got %q, want %q", got, want)
+	}
+}
+
+func TestSynthesizeCrossingGoals(t *testing.T) {
+	// From 10 to 20: R10 avoids 0, L90 passes through it.
+	cases := []struct {
+		goal day1.CrossingGoal
+		want string
+	}{
+		{day1.FewestClicks, "R10"},
+		{day1.MinCrossings, "R10"},
+		{day1.MaxCrossings, "L90"},
+	}
+	for _, tt := range cases {
+		ins, err := day1.Synthesize(10, []int{20}, tt.goal)
+		if err != nil {
+			t.Fatalf("Synthesize error: %v", err)
+		}
+		if got := day1.FormatInstructions(ins); got != tt.want {
+			t.Fatalf("goal %d: got %q, want %q", tt.goal, got, tt.want)
+		}
+	}
+}
+
+func TestSynthesizeRoundTripsThroughCompute(t *testing.T) {
+	targets := []int{82, 52, 0, 95, 55, 0, 99, 0, 14, 32}
+	for _, goal := range []day1.CrossingGoal{day1.FewestClicks, day1.MinCrossings, day1.MaxCrossings} {
+		var visited []int
+		opts := day1.DefaultOptions()
+		opts.OnRotate = func(e day1.Event) { visited = append(visited, e.To) }
+
+		ins, err := day1.Synthesize(day1.InitialValue, targets, goal)
+		if err != nil {
+			t.Fatalf("Synthesize error: %v", err)
+		}
+		res, err := day1.ComputeWith(bytes.NewBufferString(day1.FormatInstructions(ins)), opts)
+		if err != nil {
+			t.Fatalf("ComputeWith error: %v", err)
+		}
+		if len(visited) != len(targets) {
+			t.Fatalf("goal %d: visited %v, want %v", goal, visited, targets)
+		}
+		for i := range targets {
+			if visited[i] != targets[i] {
+				t.Fatalf("goal %d: visited %v, want %v", goal, visited, targets)
+			}
+		}
+		if res.ZeroStops != 3 {
+			t.Fatalf("goal %d: ZeroStops=%d, want 3", goal, res.ZeroStops)
+		}
+	}
+}
+
+func TestSynthesizeWithCustomDial(t *testing.T) {
+	// A 12-position dial starting at 3 and counting crossings of 6.
+	opts := day1.Options{DialSize: 12, Start: 3, Targets: []int{6}}
+	stops := []int{9, 0, 11}
+	cases := []struct {
+		goal day1.CrossingGoal
+		want string
+	}{
+		{day1.FewestClicks, "R6 R3 L1"},
+		{day1.MinCrossings, "L6 R3 L1"},
+		{day1.MaxCrossings, "R6 L9 R11"},
+	}
+	for _, tt := range cases {
+		ins, err := day1.SynthesizeWith(opts, stops, tt.goal)
+		if err != nil {
+			t.Fatalf("SynthesizeWith error: %v", err)
+		}
+		if got := day1.FormatInstructions(ins); got != tt.want {
+			t.Fatalf("goal %d: got %q, want %q", tt.goal, got, tt.want)
+		}
+
+		var visited []int
+		run := opts
+		run.OnRotate = func(e day1.Event) { visited = append(visited, e.To) }
+		if _, err := day1.ComputeWith(bytes.NewBufferString(tt.want), run); err != nil {
+			t.Fatalf("ComputeWith error: %v", err)
+		}
+		if fmt.Sprint(visited) != fmt.Sprint(stops) {
+			t.Fatalf("goal %d: visited %v, want %v", tt.goal, visited, stops)
+		}
+	}
+
+	if _, err := day1.SynthesizeWith(opts, []int{12}, day1.FewestClicks); !errors.Is(err, day1.ErrInvalidPosition) {
+		t.Fatalf("stop outside a 12-position dial: got %v, want ErrInvalidPosition", err)
+	}
+}
+
+func TestSynthesizeRejectsInvalidPositions(t *testing.T) {
+	if _, err := day1.Synthesize(100, []int{1}, day1.FewestClicks); err == nil {
+		t.Fatalf("expected error for start outside the dial")
+	}
+	if _, err := day1.Synthesize(0, []int{1, -1}, day1.FewestClicks); err == nil {
+		t.Fatalf("expected error for target outside the dial")
+	}
+}
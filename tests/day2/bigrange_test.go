@@ -0,0 +1,211 @@
+package day2_test
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"adventofcode2025/day1/src/day2"
+)
+
+func TestComputeBigBounds(t *testing.T) {
+	input := "95-115,99999999999999999990-100000000000000000010,123412341234123412341234-123412341234123412341235"
+	res, err := day2.Compute(bytes.NewBufferString(input))
+	if err != nil {
+		t.Fatalf("Compute error: %v", err)
+	}
+
+	expected := []int64{99, 111}
+	if len(res.InvalidIDs) != len(expected) {
+		t.Fatalf("got %v, want %v", res.InvalidIDs, expected)
+	}
+	for i, v := range expected {
+		if res.InvalidIDs[i] != v {
+			t.Fatalf("invalid ID %d: got %d, want %d", i, res.InvalidIDs[i], v)
+		}
+	}
+
+	expectedBig := []string{"99999999999999999999", "123412341234123412341234"}
+	if len(res.BigInvalidIDs) != len(expectedBig) {
+		t.Fatalf("got %v, want %v", res.BigInvalidIDs, expectedBig)
+	}
+	sum := big.NewInt(99 + 111)
+	for i, v := range expectedBig {
+		if res.BigInvalidIDs[i].String() != v {
+			t.Fatalf("big invalid ID %d: got %s, want %s", i, res.BigInvalidIDs[i], v)
+		}
+		sum.Add(sum, res.BigInvalidIDs[i])
+	}
+	if res.BigSum == nil || res.BigSum.Cmp(sum) != 0 || res.Sum != math.MaxInt64 {
+		t.Fatalf("got (%d,%v), want (MaxInt64,%s)", res.Sum, res.BigSum, sum)
+	}
+
+	totals, err := day2.ComputeBigTotals(bytes.NewBufferString(input), day2.Options{})
+	if err != nil {
+		t.Fatalf("ComputeBigTotals error: %v", err)
+	}
+	if totals.Count.Int64() != 4 || totals.Sum.Cmp(sum) != 0 {
+		t.Fatalf("ComputeBigTotals got (%s,%s), want (4,%s)", totals.Count, totals.Sum, sum)
+	}
+}
+
+func TestComputeInt64SumOverflow(t *testing.T) {
+	input := "8888888888888888888-8888888888888888888,7777777777777777777-7777777777777777777"
+	res, err := day2.Compute(bytes.NewBufferString(input))
+	if err != nil {
+		t.Fatalf("Compute error: %v", err)
+	}
+	if res.BigSum == nil || res.BigSum.String() != "16666666666666666665" {
+		t.Fatalf("BigSum=%v, want 16666666666666666665", res.BigSum)
+	}
+	if len(res.InvalidIDs) != 2 || res.BigInvalidIDs != nil {
+		t.Fatalf("got IDs %v and %v", res.InvalidIDs, res.BigInvalidIDs)
+	}
+}
+
+func TestBigPathMatchesInt64Path(t *testing.T) {
+	// The trailing huge range forces the big path for the whole input.
+	small := "1-5000000,123123-999999"
+	forced := small + ",99999999999999999999-99999999999999999999"
+	for _, spec := range []string{"atleast:2", "exactly:2", "atmost:3"} {
+		rule, err := day2.ParseRule(spec)
+		if err != nil {
+			t.Fatalf("ParseRule error: %v", err)
+		}
+		opts := day2.Options{Rule: rule}
+
+		want, err := day2.ComputeWith(bytes.NewBufferString(small), opts)
+		if err != nil {
+			t.Fatalf("ComputeWith error: %v", err)
+		}
+		got, err := day2.ComputeWith(bytes.NewBufferString(forced), opts)
+		if err != nil {
+			t.Fatalf("ComputeWith error: %v", err)
+		}
+		if len(got.InvalidIDs) != len(want.InvalidIDs) {
+			t.Fatalf("%s: got %d IDs, want %d", spec, len(got.InvalidIDs), len(want.InvalidIDs))
+		}
+		for i := range want.InvalidIDs {
+			if got.InvalidIDs[i] != want.InvalidIDs[i] {
+				t.Fatalf("%s: ID %d got %d, want %d", spec, i, got.InvalidIDs[i], want.InvalidIDs[i])
+			}
+		}
+	}
+}
+
+func TestComputeBigTotalsMatchesBigStream(t *testing.T) {
+	input := "100000000000000000000000-100000100000000000000000,111111111111111111111110-111111111111111111111112,5-10000000"
+	for _, spec := range []string{"atleast:2", "exactly:3", "atmost:4", "atleast:3/16"} {
+		rule, err := day2.ParseRule(spec)
+		if err != nil {
+			t.Fatalf("ParseRule error: %v", err)
+		}
+		opts := day2.Options{Rule: rule}
+
+		ids, err := day2.ComputeBigStream(bytes.NewBufferString(input), opts)
+		if err != nil {
+			t.Fatalf("ComputeBigStream error: %v", err)
+		}
+		count, sum := new(big.Int), new(big.Int)
+		var prev *big.Int
+		for id := range ids {
+			if prev != nil && id.Cmp(prev) <= 0 {
+				t.Fatalf("%s: stream not strictly increasing at %s", spec, id)
+			}
+			prev = id
+			count.Add(count, big.NewInt(1))
+			sum.Add(sum, id)
+			if count.Int64() > 1000000 {
+				t.Fatalf("%s: too many IDs for this test", spec)
+			}
+		}
+
+		totals, err := day2.ComputeBigTotals(bytes.NewBufferString(input), opts)
+		if err != nil {
+			t.Fatalf("ComputeBigTotals error: %v", err)
+		}
+		if totals.Count.Cmp(count) != 0 || totals.Sum.Cmp(sum) != 0 {
+			t.Fatalf("%s: got (%s,%s), want (%s,%s)", spec, totals.Count, totals.Sum, count, sum)
+		}
+	}
+}
+
+// mobius returns the Möbius function of n.
+func mobius(n int) int {
+	mu := 1
+	for p := 2; p*p <= n; p++ {
+		if n%p != 0 {
+			continue
+		}
+		n /= p
+		if n%p == 0 {
+			return 0
+		}
+		mu = -mu
+	}
+	if n > 1 {
+		mu = -mu
+	}
+	return mu
+}
+
+func TestComputeBigTotalsHundredsOfDigits(t *testing.T) {
+	const digits = 720
+	rule, err := day2.ParseRule("atleast:2")
+	if err != nil {
+		t.Fatalf("ParseRule error: %v", err)
+	}
+
+	// An ID of L digits is invalid when its smallest period p is a proper
+	// divisor of L; there are sum over d|p of mu(p/d)*9*10^(d-1) blocks of
+	// p digits with smallest period p.
+	ten := big.NewInt(10)
+	want := new(big.Int)
+	for length := 2; length <= digits; length++ {
+		for p := 1; p < length; p++ {
+			if length%p != 0 {
+				continue
+			}
+			for d := 1; d <= p; d++ {
+				if p%d != 0 {
+					continue
+				}
+				blocks := new(big.Int).Exp(ten, big.NewInt(int64(d-1)), nil)
+				blocks.Mul(blocks, big.NewInt(int64(9*mobius(p/d))))
+				want.Add(want, blocks)
+			}
+		}
+	}
+
+	input := "1-1" + strings.Repeat("0", digits)
+	done := make(chan day2.BigTotals, 1)
+	go func() {
+		totals, err := day2.ComputeBigTotals(bytes.NewBufferString(input), day2.Options{Rule: rule})
+		if err != nil {
+			t.Errorf("ComputeBigTotals error: %v", err)
+		}
+		done <- totals
+	}()
+	select {
+	case totals := <-done:
+		if totals.Count == nil || totals.Count.Cmp(want) != 0 {
+			t.Fatalf("Count=%v, want %s", totals.Count, want)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("ComputeBigTotals did not return for a %d-digit bound", digits)
+	}
+}
+
+func TestComputeStreamRejectsBigBounds(t *testing.T) {
+	_, err := day2.ComputeStream(bytes.NewBufferString("1-99999999999999999999"), day2.Options{})
+	if !errors.Is(err, day2.ErrInvalidBoundary) {
+		t.Fatalf("got %v, want ErrInvalidBoundary", err)
+	}
+	if _, err := day2.ComputeTotals(bytes.NewBufferString("1-99999999999999999999999999"), day2.Options{}); !errors.Is(err, day2.ErrSumOverflow) {
+		t.Fatalf("got %v, want ErrSumOverflow", err)
+	}
+}
@@ -87,3 +87,46 @@ func TestComputeHandlesMultiRepeats(t *testing.T) {
 		t.Fatalf("sum mismatch: got %d, want %d", result.Sum, expectedSum)
 	}
 }
+
+func TestComputeExactlyTwiceExample(t *testing.T) {
+	input := `11-22,95-115,998-1012,1188511880-1188511890,222220-222224,
+1698522-1698528,446443-446449,38593856-38593862,565653-565659,
+824824821-824824827,2121212118-2121212124`
+
+	result, err := day2.ComputeWith(bytes.NewBufferString(input), day2.Options{Mode: day2.RepeatExactlyTwice})
+	if err != nil {
+		t.Fatalf("ComputeWith error: %v", err)
+	}
+
+	expected := []int64{11, 22, 99, 1010, 222222, 446446, 38593859, 1188511885}
+	if len(result.InvalidIDs) != len(expected) {
+		t.Fatalf("got %d invalid IDs, want %d", len(result.InvalidIDs), len(expected))
+	}
+	for i, v := range expected {
+		if result.InvalidIDs[i] != v {
+			t.Fatalf("invalid ID %d: got %d, want %d", i, result.InvalidIDs[i], v)
+		}
+	}
+	if result.Sum != 1227775554 {
+		t.Fatalf("sum mismatch: got %d, want 1227775554", result.Sum)
+	}
+}
+
+func TestComputeExactlyTwiceRejectsLongerRepeats(t *testing.T) {
+	input := "111-111,1111-1111,646464-646464,123123123-123123123,12121212-12121212"
+	result, err := day2.ComputeWith(bytes.NewBufferString(input), day2.Options{Mode: day2.RepeatExactlyTwice})
+	if err != nil {
+		t.Fatalf("ComputeWith error: %v", err)
+	}
+
+	// 1111 is 11 twice and 12121212 is 1212 twice; the others only repeat oddly.
+	expected := []int64{1111, 12121212}
+	if len(result.InvalidIDs) != len(expected) {
+		t.Fatalf("expected %d IDs, got %v", len(expected), result.InvalidIDs)
+	}
+	for i, v := range expected {
+		if result.InvalidIDs[i] != v {
+			t.Fatalf("invalid ID %d: got %d, want %d", i, result.InvalidIDs[i], v)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package day2_test
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"adventofcode2025/day1/src/day2"
+)
+
+func TestPointQueriesMatchBruteForce(t *testing.T) {
+	const limit = 20000
+	for _, spec := range []string{"atleast:2", "exactly:2", "atmost:3", "exactly:3/2", "atleast:2/16"} {
+		rule, err := day2.ParseRule(spec)
+		if err != nil {
+			t.Fatalf("ParseRule(%s) error: %v", spec, err)
+		}
+
+		// Collect invalid IDs up to the first one past limit.
+		var invalid []int64
+		for id := int64(0); len(invalid) == 0 || invalid[len(invalid)-1] <= limit; id++ {
+			if bruteMatches(id, rule) {
+				invalid = append(invalid, id)
+			}
+		}
+
+		rank := int64(0)
+		next := 0
+		for n := int64(0); n <= limit; n++ {
+			ok, err := day2.IsInvalid(n, rule)
+			if err != nil {
+				t.Fatalf("IsInvalid error: %v", err)
+			}
+			if ok != bruteMatches(n, rule) {
+				t.Fatalf("%s: IsInvalid(%d)=%v", spec, n, ok)
+			}
+			if ok {
+				rank++
+				next++
+			}
+
+			gotRank, err := day2.Rank(n, rule)
+			if err != nil || gotRank != rank {
+				t.Fatalf("%s: Rank(%d)=%d (%v), want %d", spec, n, gotRank, err, rank)
+			}
+
+			gotNext, err := day2.NextInvalid(n, rule)
+			if err != nil || gotNext != invalid[next] {
+				t.Fatalf("%s: NextInvalid(%d)=%d (%v), want %d", spec, n, gotNext, err, invalid[next])
+			}
+
+			gotPrev, err := day2.PrevInvalid(n, rule)
+			prevIdx := next - 1
+			if ok {
+				prevIdx--
+			}
+			if prevIdx < 0 {
+				if !errors.Is(err, day2.ErrNoInvalidID) {
+					t.Fatalf("%s: PrevInvalid(%d)=%d (%v), want ErrNoInvalidID", spec, n, gotPrev, err)
+				}
+				continue
+			}
+			if err != nil || gotPrev != invalid[prevIdx] {
+				t.Fatalf("%s: PrevInvalid(%d)=%d (%v), want %d", spec, n, gotPrev, err, invalid[prevIdx])
+			}
+		}
+	}
+}
+
+func TestPointQueriesLargeValues(t *testing.T) {
+	var rule day2.Rule
+
+	next, err := day2.NextInvalid(1188511880, rule)
+	if err != nil || next != 1188511885 {
+		t.Fatalf("NextInvalid got %d (%v), want 1188511885", next, err)
+	}
+	prev, err := day2.PrevInvalid(1000000000000000000, rule)
+	if err != nil || prev != 999999999999999999 {
+		t.Fatalf("PrevInvalid got %d (%v), want 999999999999999999", prev, err)
+	}
+	// No 19-digit ID made of repeated blocks fits below math.MaxInt64 except
+	// those of a single repeated digit up to 8888888888888888888.
+	last, err := day2.PrevInvalid(math.MaxInt64, rule)
+	if err != nil || last != 8888888888888888888 {
+		t.Fatalf("PrevInvalid(MaxInt64) got %d (%v)", last, err)
+	}
+	if _, err := day2.NextInvalid(last, rule); !errors.Is(err, day2.ErrNoInvalidID) {
+		t.Fatalf("NextInvalid(%d) error=%v, want ErrNoInvalidID", last, err)
+	}
+
+	rank, err := day2.Rank(999999999999, rule)
+	if err != nil {
+		t.Fatalf("Rank error: %v", err)
+	}
+	totals, err := day2.ComputeTotals(strings.NewReader("1-999999999999"), day2.Options{})
+	if err != nil {
+		t.Fatalf("ComputeTotals error: %v", err)
+	}
+	if rank != totals.Count {
+		t.Fatalf("Rank=%d, want %d", rank, totals.Count)
+	}
+
+	if _, err := day2.IsInvalid(11, day2.Rule{Count: 1}); !errors.Is(err, day2.ErrInvalidRule) {
+		t.Fatalf("got %v, want ErrInvalidRule", err)
+	}
+}
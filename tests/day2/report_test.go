@@ -0,0 +1,138 @@
+package day2_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"adventofcode2025/day1/src/aoc"
+	"adventofcode2025/day1/src/day2"
+)
+
+func TestComputeReportPerRange(t *testing.T) {
+	rep, err := day2.ComputeReport(strings.NewReader("11-22,95-115,100-120,1698522-1698528"), day2.Options{})
+	if err != nil {
+		t.Fatalf("ComputeReport error: %v", err)
+	}
+
+	want := []struct {
+		count, sum, shared string
+		overlaps           string
+	}{
+		{"2", "33", "0", ""},
+		{"2", "210", "1", "100-115:1[111]"},
+		{"1", "111", "1", "100-115:1[111]"},
+		{"0", "0", "0", ""},
+	}
+	if len(rep.Ranges) != len(want) {
+		t.Fatalf("got %d ranges, want %d", len(rep.Ranges), len(want))
+	}
+	for i, w := range want {
+		rr := rep.Ranges[i]
+		var overlaps []string
+		for _, o := range rr.Overlaps {
+			overlaps = append(overlaps, fmt.Sprintf("%s-%s:%s%v", o.Start, o.End, o.Count, o.IDs))
+		}
+		got := strings.Join(overlaps, ",")
+		if rr.Count.String() != w.count || rr.Sum.String() != w.sum || rr.Shared.String() != w.shared || got != w.overlaps {
+			t.Fatalf("range %d: count=%s sum=%s shared=%s overlaps=[%s], want %s %s %s [%s]",
+				i, rr.Count, rr.Sum, rr.Shared, got, w.count, w.sum, w.shared, w.overlaps)
+		}
+	}
+	// 111 belongs to two ranges but is only counted once overall.
+	if rep.Count.String() != "4" || rep.Sum.String() != "243" {
+		t.Fatalf("totals: count=%s sum=%s, want 4 243", rep.Count, rep.Sum)
+	}
+}
+
+func TestComputeReportHugeOverlaps(t *testing.T) {
+	huge := "1" + strings.Repeat("0", 40)
+	input := "1-" + huge + ",5-" + huge + ",20-30,1000-1010"
+	rep, err := day2.ComputeReport(strings.NewReader(input), day2.Options{})
+	if err != nil {
+		t.Fatalf("ComputeReport error: %v", err)
+	}
+	totals, err := day2.ComputeBigTotals(strings.NewReader("5-"+huge), day2.Options{})
+	if err != nil {
+		t.Fatalf("ComputeBigTotals error: %v", err)
+	}
+
+	// The first range shares everything past 4, in a single stretch.
+	first := rep.Ranges[0]
+	if len(first.Overlaps) != 1 || first.Overlaps[0].Start.String() != "5" || first.Shared.Cmp(totals.Count) != 0 {
+		t.Fatalf("first range: shared=%s overlaps=%+v, want one stretch from 5 with %s IDs", first.Shared, first.Overlaps, totals.Count)
+	}
+	// Only the first SharedIDLimit shared IDs are listed.
+	ids := first.Overlaps[0].IDs
+	if len(ids) != day2.SharedIDLimit || ids[0].String() != "11" || ids[9].String() != "111" {
+		t.Fatalf("first range lists %d IDs starting %v, want %d from 11", len(ids), ids[:min(len(ids), 10)], day2.SharedIDLimit)
+	}
+	small := rep.Ranges[2]
+	if len(small.Overlaps) != 1 || small.Shared.String() != "1" || len(small.Overlaps[0].IDs) != 1 || small.Overlaps[0].IDs[0].String() != "22" {
+		t.Fatalf("20-30: shared=%s overlaps=%+v, want 22 shared once", small.Shared, small.Overlaps)
+	}
+
+	var table bytes.Buffer
+	if err := rep.WriteTable(&table); err != nil {
+		t.Fatalf("WriteTable error: %v", err)
+	}
+	if !strings.Contains(table.String(), ",... ("+first.Shared.String()+" in all)") {
+		t.Fatalf("table does not flag the truncated list:\n%s", table.String())
+	}
+}
+
+func TestComputeReportMatchesTotals(t *testing.T) {
+	input := "11-22,95-115,998-1012,1188511880-1188511890,222220-222224,1698522-1698528,446443-446449,38593856-38593862,565653-565659,824824821-824824827,2121212118-2121212124"
+	for _, opts := range []day2.Options{{}, {Mode: day2.RepeatExactlyTwice}} {
+		rep, err := day2.ComputeReport(strings.NewReader(input), opts)
+		if err != nil {
+			t.Fatalf("ComputeReport error: %v", err)
+		}
+		totals, err := day2.ComputeTotals(strings.NewReader(input), opts)
+		if err != nil {
+			t.Fatalf("ComputeTotals error: %v", err)
+		}
+		if rep.Sum.Int64() != totals.Sum || rep.Count.Int64() != totals.Count {
+			t.Fatalf("mode %d: report totals %s/%s, want %d/%d", opts.Mode, rep.Count, rep.Sum, totals.Count, totals.Sum)
+		}
+	}
+}
+
+func TestReportFormats(t *testing.T) {
+	var table bytes.Buffer
+	if err := aoc.Report(2, 2, "table", strings.NewReader("11-22,15-33"), &table); err != nil {
+		t.Fatalf("table report error: %v", err)
+	}
+	wantTable := "RANGE  COUNT  SUM  SHARED\n" +
+		"11-22  2      33   22\n" +
+		"15-33  2      55   22\n" +
+		"TOTAL  3      66\n"
+	if table.String() != wantTable {
+		t.Fatalf("table report:\n%s\nwant:\n%s", table.String(), wantTable)
+	}
+
+	var out bytes.Buffer
+	if err := aoc.Report(2, 1, "json", strings.NewReader("11-22,15-33"), &out); err != nil {
+		t.Fatalf("json report error: %v", err)
+	}
+	var decoded struct {
+		Ranges []struct {
+			Start, End, Count, Sum, Shared int64
+			Overlaps                       []struct {
+				Start, End, Count int64
+				IDs               []int64
+			}
+		}
+		Count, Sum int64
+	}
+	if err := json.Unmarshal(out.Bytes(), &decoded); err != nil {
+		t.Fatalf("json report does not decode: %v\n%s", err, out.String())
+	}
+	if len(decoded.Ranges) != 2 || decoded.Ranges[1].Start != 15 || decoded.Sum != 66 ||
+		decoded.Ranges[0].Shared != 1 || len(decoded.Ranges[0].Overlaps) != 1 || decoded.Ranges[0].Overlaps[0].End != 22 ||
+		len(decoded.Ranges[0].Overlaps[0].IDs) != 1 || decoded.Ranges[0].Overlaps[0].IDs[0] != 22 {
+		t.Fatalf("unexpected json report: %+v", decoded)
+	}
+}
@@ -0,0 +1,132 @@
+package day2_test
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"adventofcode2025/day1/src/day2"
+)
+
+// bruteRepeats returns the repeat counts r >= 2 for which the base-b digits of
+// id are a single block repeated r times.
+func bruteRepeats(id int64, base int) []int {
+	digits := strconv.FormatInt(id, base)
+	var repeats []int
+	for size := 1; size <= len(digits)/2; size++ {
+		if len(digits)%size != 0 {
+			continue
+		}
+		if strings.Repeat(digits[:size], len(digits)/size) == digits {
+			repeats = append(repeats, len(digits)/size)
+		}
+	}
+	return repeats
+}
+
+func bruteMatches(id int64, rule day2.Rule) bool {
+	base := rule.Base
+	if base == 0 {
+		base = 10
+	}
+	for _, r := range bruteRepeats(id, base) {
+		switch rule.Kind {
+		case day2.Exactly:
+			if r == rule.Count {
+				return true
+			}
+		case day2.AtLeast:
+			if r >= rule.Count {
+				return true
+			}
+		case day2.AtMost:
+			if r <= rule.Count {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func TestComputeWithRuleMatchesBruteForce(t *testing.T) {
+	rules := []string{
+		"atleast:2", "atleast:3", "exactly:2", "exactly:3", "atmost:2", "atmost:3",
+		"atleast:2/2", "exactly:4/2", "atmost:3/2", "atleast:2/16", "exactly:3/16", "exactly:2/36",
+	}
+	for _, spec := range rules {
+		rule, err := day2.ParseRule(spec)
+		if err != nil {
+			t.Fatalf("ParseRule(%s) error: %v", spec, err)
+		}
+		base := rule.Base
+		if base == 0 {
+			base = 10
+		}
+
+		const lo, hi = 0, 70000
+		input := strconv.FormatInt(lo, base) + "-" + strconv.FormatInt(hi, base)
+		res, err := day2.ComputeWith(bytes.NewBufferString(input), day2.Options{Rule: rule})
+		if err != nil {
+			t.Fatalf("%s: ComputeWith error: %v", spec, err)
+		}
+
+		var want []int64
+		var sum int64
+		for id := int64(lo); id <= hi; id++ {
+			if bruteMatches(id, rule) {
+				want = append(want, id)
+				sum += id
+			}
+		}
+		if len(res.InvalidIDs) != len(want) {
+			t.Fatalf("%s: got %d IDs, want %d", spec, len(res.InvalidIDs), len(want))
+		}
+		for i := range want {
+			if res.InvalidIDs[i] != want[i] {
+				t.Fatalf("%s: ID %d got %d, want %d", spec, i, res.InvalidIDs[i], want[i])
+			}
+		}
+		if res.Sum != sum {
+			t.Fatalf("%s: sum got %d, want %d", spec, res.Sum, sum)
+		}
+	}
+}
+
+func TestComputeWithHexRanges(t *testing.T) {
+	rule := day2.Rule{Kind: day2.Exactly, Count: 2, Base: 16}
+	res, err := day2.ComputeWith(bytes.NewBufferString("a0-ff,abab-abac"), day2.Options{Rule: rule})
+	if err != nil {
+		t.Fatalf("ComputeWith error: %v", err)
+	}
+	// aa, bb, cc, dd, ee, ff and abab.
+	expected := []int64{0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff, 0xabab}
+	if len(res.InvalidIDs) != len(expected) {
+		t.Fatalf("got %v, want %v", res.InvalidIDs, expected)
+	}
+	for i, v := range expected {
+		if res.InvalidIDs[i] != v {
+			t.Fatalf("invalid ID %d: got %d, want %d", i, res.InvalidIDs[i], v)
+		}
+	}
+}
+
+func TestParseRule(t *testing.T) {
+	rule, err := day2.ParseRule("atmost:4/2")
+	if err != nil {
+		t.Fatalf("ParseRule error: %v", err)
+	}
+	if rule != (day2.Rule{Kind: day2.AtMost, Count: 4, Base: 2}) {
+		t.Fatalf("ParseRule got %+v", rule)
+	}
+	if rule.String() != "atmost:4/2" {
+		t.Fatalf("String got %q", rule.String())
+	}
+
+	for _, bad := range []string{"", "exactly", "twice:2", "exactly:x", "exactly:1", "atleast:2/1", "atleast:2/37", "atleast:2/x"} {
+		if _, err := day2.ParseRule(bad); !errors.Is(err, day2.ErrInvalidRule) {
+			t.Fatalf("ParseRule(%q) error=%v, want ErrInvalidRule", bad, err)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package day2_test
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"testing"
+
+	"adventofcode2025/day1/src/day2"
+)
+
+func TestComputeStreamSortedAndDeduplicated(t *testing.T) {
+	input := "998-1012,11-22,20-44,95-115,11-11,1000-1500"
+	ids, err := day2.ComputeStream(bytes.NewBufferString(input), day2.Options{})
+	if err != nil {
+		t.Fatalf("ComputeStream error: %v", err)
+	}
+
+	var got []int64
+	for id := range ids {
+		got = append(got, id)
+	}
+	expected := []int64{11, 22, 33, 44, 99, 111, 999, 1010, 1111, 1212, 1313, 1414}
+	if len(got) != len(expected) {
+		t.Fatalf("got %v, want %v", got, expected)
+	}
+	for i, v := range expected {
+		if got[i] != v {
+			t.Fatalf("ID %d: got %d, want %d", i, got[i], v)
+		}
+	}
+}
+
+func TestComputeStreamStopsEarly(t *testing.T) {
+	ids, err := day2.ComputeStream(bytes.NewBufferString("1-999999999999999999"), day2.Options{})
+	if err != nil {
+		t.Fatalf("ComputeStream error: %v", err)
+	}
+
+	var got []int64
+	for id := range ids {
+		got = append(got, id)
+		if len(got) == 12 {
+			break
+		}
+	}
+	if got[8] != 99 || got[9] != 111 || got[11] != 333 {
+		t.Fatalf("unexpected prefix %v", got)
+	}
+}
+
+func TestComputeTotalsMatchesEnumeration(t *testing.T) {
+	inputs := []string{
+		`11-22,95-115,998-1012,1188511880-1188511890,222220-222224,
+1698522-1698528,446443-446449,38593856-38593862,565653-565659,
+824824821-824824827,2121212118-2121212124`,
+		"1-1000000000000,5-10,123456-99999999",
+	}
+	rules := []string{"atleast:2", "exactly:2", "exactly:3", "atmost:3"}
+	for _, input := range inputs {
+		for _, spec := range rules {
+			rule, err := day2.ParseRule(spec)
+			if err != nil {
+				t.Fatalf("ParseRule(%s) error: %v", spec, err)
+			}
+			opts := day2.Options{Rule: rule}
+
+			ids, err := day2.ComputeStream(bytes.NewBufferString(input), opts)
+			if err != nil {
+				t.Fatalf("ComputeStream error: %v", err)
+			}
+			var want day2.Totals
+			for id := range ids {
+				want.Count++
+				want.Sum += id
+			}
+
+			got, err := day2.ComputeTotals(bytes.NewBufferString(input), opts)
+			if err != nil {
+				t.Fatalf("ComputeTotals error: %v", err)
+			}
+			if got != want {
+				t.Fatalf("%s: got %+v, want %+v", spec, got, want)
+			}
+		}
+	}
+}
+
+func TestComputeTotalsBruteForce(t *testing.T) {
+	for _, spec := range []string{"atleast:2", "exactly:2", "atmost:2", "atleast:3", "exactly:4/2", "atmost:4/2", "atleast:2/36"} {
+		rule, err := day2.ParseRule(spec)
+		if err != nil {
+			t.Fatalf("ParseRule(%s) error: %v", spec, err)
+		}
+		base := rule.Base
+		if base == 0 {
+			base = 10
+		}
+
+		var want day2.Totals
+		for id := int64(3000); id <= 150000; id++ {
+			if bruteMatches(id, rule) {
+				want.Count++
+				want.Sum += id
+			}
+		}
+		input := strconv.FormatInt(3000, base) + "-" + strconv.FormatInt(150000, base)
+		got, err := day2.ComputeTotals(bytes.NewBufferString(input), day2.Options{Rule: rule})
+		if err != nil {
+			t.Fatalf("ComputeTotals error: %v", err)
+		}
+		if got != want {
+			t.Fatalf("%s: got %+v, want %+v", spec, got, want)
+		}
+	}
+}
+
+func TestComputeTotalsOverflow(t *testing.T) {
+	_, err := day2.ComputeTotals(bytes.NewBufferString("1-9223372036854775807"), day2.Options{})
+	if !errors.Is(err, day2.ErrSumOverflow) {
+		t.Fatalf("got %v, want ErrSumOverflow", err)
+	}
+}
@@ -0,0 +1,70 @@
+package day3_test
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"adventofcode2025/day1/src/day3"
+)
+
+const example = `987654321111111
+811111111111119
+234234234234278
+818181911112111`
+
+func TestComputeWithBatteryCount(t *testing.T) {
+	cases := []struct {
+		batteries int
+		want      int64
+	}{
+		{1, 9 + 9 + 8 + 9},
+		{2, 98 + 89 + 78 + 92},
+		{12, 3121910778619},
+		{0, 3121910778619},
+		{15, 987654321111111 + 811111111111119 + 234234234234278 + 818181911112111},
+	}
+	for _, tt := range cases {
+		result, err := day3.ComputeWith(strings.NewReader(example), day3.Options{Batteries: tt.batteries})
+		if err != nil {
+			t.Fatalf("batteries %d: ComputeWith error: %v", tt.batteries, err)
+		}
+		if result.Total != tt.want || result.BigTotal != nil {
+			t.Fatalf("batteries %d: Total=%d BigTotal=%v, want %d", tt.batteries, result.Total, result.BigTotal, tt.want)
+		}
+	}
+}
+
+func TestComputeWithBigJoltage(t *testing.T) {
+	// Each bank is worth 10^19-1, which does not fit in an int64.
+	input := "1" + strings.Repeat("9", 19) + "\n" + strings.Repeat("9", 20) + "\n"
+	result, err := day3.ComputeWith(strings.NewReader(input), day3.Options{Batteries: 19})
+	if err != nil {
+		t.Fatalf("ComputeWith error: %v", err)
+	}
+	if result.Total != math.MaxInt64 || result.BigTotal == nil || result.BigTotal.String() != "19999999999999999998" {
+		t.Fatalf("Total=%d BigTotal=%v, want saturated total and 19999999999999999998", result.Total, result.BigTotal)
+	}
+}
+
+func TestComputeWithTotalOverflow(t *testing.T) {
+	// Every joltage fits in an int64 but their sum does not.
+	input := strings.Repeat(strings.Repeat("9", 18)+"\n", 10)
+	result, err := day3.ComputeWith(strings.NewReader(input), day3.Options{Batteries: 18})
+	if err != nil {
+		t.Fatalf("ComputeWith error: %v", err)
+	}
+	if result.Banks != 10 || result.BigTotal == nil || result.BigTotal.String() != "9999999999999999990" {
+		t.Fatalf("Banks=%d BigTotal=%v, want 10 banks and 9999999999999999990", result.Banks, result.BigTotal)
+	}
+}
+
+func TestComputeWithErrors(t *testing.T) {
+	if _, err := day3.ComputeWith(strings.NewReader(example), day3.Options{Batteries: -1}); !errors.Is(err, day3.ErrInvalidOptions) {
+		t.Fatalf("negative count: got %v, want ErrInvalidOptions", err)
+	}
+	if _, err := day3.ComputeWith(strings.NewReader("12345\n"), day3.Options{Batteries: 6}); !errors.Is(err, day3.ErrBankTooShort) {
+		t.Fatalf("short bank: got %v, want ErrBankTooShort", err)
+	}
+}
@@ -0,0 +1,114 @@
+package day3_test
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+
+	"adventofcode2025/day1/src/day3"
+)
+
+// bruteJoltages returns every distinct joltage of count batteries of the
+// bank, highest first.
+func bruteJoltages(bank string, count int) []string {
+	seen := make(map[string]bool)
+	var walk func(pos int, prefix string)
+	walk = func(pos int, prefix string) {
+		if len(prefix) == count {
+			seen[prefix] = true
+			return
+		}
+		for i := pos; i < len(bank); i++ {
+			walk(i+1, prefix+bank[i:i+1])
+		}
+	}
+	walk(0, "")
+
+	res := make([]string, 0, len(seen))
+	for j := range seen {
+		res = append(res, j)
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(res)))
+	return res
+}
+
+func TestTopSelectionsMatchBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	for iter := 0; iter < 200; iter++ {
+		n := 1 + rng.Intn(10)
+		digits := 1 + rng.Intn(10)
+		var b strings.Builder
+		for i := 0; i < n; i++ {
+			b.WriteByte(byte('0' + rng.Intn(digits)))
+		}
+		bank := b.String()
+		count := 1 + rng.Intn(n)
+		want := bruteJoltages(bank, count)
+
+		top, err := day3.TopSelections(bank, count, len(want)+1)
+		if err != nil {
+			t.Fatalf("TopSelections(%s, %d) error: %v", bank, count, err)
+		}
+		if len(top) != len(want) {
+			t.Fatalf("TopSelections(%s, %d) returned %d joltages, want %d", bank, count, len(top), len(want))
+		}
+		for i, s := range top {
+			if s.Joltage != want[i] {
+				t.Fatalf("TopSelections(%s, %d)[%d]=%s, want %s", bank, count, i, s.Joltage, want[i])
+			}
+			for j, idx := range s.Chosen {
+				if bank[idx] != s.Joltage[j] {
+					t.Fatalf("TopSelections(%s, %d)[%d]: index %d does not give digit %c", bank, count, i, idx, s.Joltage[j])
+				}
+			}
+		}
+
+		min, err := day3.MinSelection(bank, count)
+		if err != nil {
+			t.Fatalf("MinSelection error: %v", err)
+		}
+		if min.Joltage != want[len(want)-1] {
+			t.Fatalf("MinSelection(%s, %d)=%s, want %s", bank, count, min.Joltage, want[len(want)-1])
+		}
+	}
+}
+
+func TestTopSelectionsBestMatchesExplain(t *testing.T) {
+	for _, bank := range strings.Split(example, "\n") {
+		top, err := day3.TopSelections(bank, 12, 3)
+		if err != nil {
+			t.Fatalf("TopSelections error: %v", err)
+		}
+		best, err := day3.Explain(bank, 12)
+		if err != nil {
+			t.Fatalf("Explain error: %v", err)
+		}
+		if len(top) != 3 || top[0].Joltage != best.Joltage || !(top[0].Joltage > top[1].Joltage && top[1].Joltage > top[2].Joltage) {
+			t.Fatalf("bank %s: top=%v, want 3 decreasing joltages starting with %s", bank, top, best.Joltage)
+		}
+	}
+}
+
+func TestComputeRanking(t *testing.T) {
+	rankings, err := day3.ComputeRanking(strings.NewReader("\n811111111111119\n"), day3.Options{Batteries: 2}, 5)
+	if err != nil {
+		t.Fatalf("ComputeRanking error: %v", err)
+	}
+	if len(rankings) != 1 {
+		t.Fatalf("got %d rankings, want 1", len(rankings))
+	}
+	r := rankings[0]
+	var got []string
+	for _, s := range r.Top {
+		got = append(got, s.Joltage)
+	}
+	if r.Line != 2 || strings.Join(got, ",") != "89,81,19,11" || r.Min.Joltage != "11" {
+		t.Fatalf("ranking line %d top %v min %s, want line 2 top [89 81 19 11] min 11", r.Line, got, r.Min.Joltage)
+	}
+
+	if _, err := day3.ComputeRanking(strings.NewReader(example), day3.Options{}, 0); !errors.Is(err, day3.ErrInvalidOptions) {
+		t.Fatalf("k=0: got %v, want ErrInvalidOptions", err)
+	}
+}
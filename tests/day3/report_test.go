@@ -0,0 +1,90 @@
+package day3_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"adventofcode2025/day1/src/aoc"
+	"adventofcode2025/day1/src/day3"
+)
+
+func TestExplainSelection(t *testing.T) {
+	sel, err := day3.Explain("818181911112111", 12)
+	if err != nil {
+		t.Fatalf("Explain error: %v", err)
+	}
+	if sel.Joltage != "888911112111" {
+		t.Fatalf("Joltage=%s, want 888911112111", sel.Joltage)
+	}
+	if want := []int{1, 3, 5}; !reflect.DeepEqual(sel.Dropped, want) {
+		t.Fatalf("Dropped=%v, want %v", sel.Dropped, want)
+	}
+	if want := []int{0, 2, 4, 6, 7, 8, 9, 10, 11, 12, 13, 14}; !reflect.DeepEqual(sel.Chosen, want) {
+		t.Fatalf("Chosen=%v, want %v", sel.Chosen, want)
+	}
+
+	if _, err := day3.Explain("123", 4); !errors.Is(err, day3.ErrBankTooShort) {
+		t.Fatalf("short bank: got %v, want ErrBankTooShort", err)
+	}
+}
+
+func TestComputeReportMatchesCompute(t *testing.T) {
+	for _, batteries := range []int{1, 2, 7, 12, 15} {
+		rep, err := day3.ComputeReport(strings.NewReader(example), day3.Options{Batteries: batteries})
+		if err != nil {
+			t.Fatalf("ComputeReport error: %v", err)
+		}
+		result, err := day3.ComputeWith(strings.NewReader(example), day3.Options{Batteries: batteries})
+		if err != nil {
+			t.Fatalf("ComputeWith error: %v", err)
+		}
+		if len(rep.Banks) != result.Banks || !rep.Total.IsInt64() || rep.Total.Int64() != result.Total {
+			t.Fatalf("batteries %d: report %d banks total %s, want %d banks total %d", batteries, len(rep.Banks), rep.Total, result.Banks, result.Total)
+		}
+		for _, s := range rep.Banks {
+			if len(s.Chosen)+len(s.Dropped) != len(s.Bank) || len(s.Joltage) != batteries {
+				t.Fatalf("batteries %d line %d: inconsistent selection %+v", batteries, s.Line, s)
+			}
+		}
+	}
+}
+
+func TestReportFormats(t *testing.T) {
+	var out bytes.Buffer
+	if err := aoc.Report(3, 1, "highlight", strings.NewReader("811111111111119\n\n1234\n"), &out); err != nil {
+		t.Fatalf("highlight report error: %v", err)
+	}
+	want := "\x1b[7m8\x1b[0m1111111111111\x1b[7m9\x1b[0m -> 89\n" +
+		"12\x1b[7m34\x1b[0m            -> 34\n" +
+		"Total           -> 123\n"
+	if out.String() != want {
+		t.Fatalf("highlight report:\n%q\nwant:\n%q", out.String(), want)
+	}
+
+	out.Reset()
+	if err := aoc.Report(3, 1, "table", strings.NewReader("1234\n"), &out); err != nil {
+		t.Fatalf("table report error: %v", err)
+	}
+	want = "LINE   SELECTED  JOLTAGE  DROPPED\n" +
+		"1      ..34      34       0,1\n" +
+		"TOTAL            34\n"
+	if out.String() != want {
+		t.Fatalf("table report:\n%s\nwant:\n%s", out.String(), want)
+	}
+
+	out.Reset()
+	if err := aoc.Report(3, 2, "json", strings.NewReader(example), &out); err != nil {
+		t.Fatalf("json report error: %v", err)
+	}
+	var decoded day3.Report
+	if err := json.Unmarshal(out.Bytes(), &decoded); err != nil {
+		t.Fatalf("json report does not decode: %v", err)
+	}
+	if len(decoded.Banks) != 4 || decoded.Banks[2].Joltage != "434234234278" || decoded.Total.String() != "3121910778619" {
+		t.Fatalf("unexpected json report: %+v", decoded)
+	}
+}
@@ -0,0 +1,118 @@
+package day3_test
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"adventofcode2025/day1/src/day3"
+)
+
+// withHeaders prefixes every non-blank bank with its length.
+func withHeaders(input string) string {
+	lines := strings.Split(input, "\n")
+	for i, line := range lines {
+		if bank := strings.TrimSpace(line); bank != "" {
+			lines[i] = fmt.Sprintf("%d:%s", len(bank), bank)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestStreamingMatchesComputeWith(t *testing.T) {
+	inputs := []string{
+		example,
+		"\n  12345678901234  \n\n21098765432109\n",
+		example + "\r\n",
+	}
+	rng := rand.New(rand.NewSource(20))
+	for i := 0; i < 50; i++ {
+		var b strings.Builder
+		for bank := 0; bank < 1+rng.Intn(4); bank++ {
+			for j := 0; j < 20+rng.Intn(30); j++ {
+				b.WriteByte(byte('0' + rng.Intn(1+rng.Intn(10))))
+			}
+			b.WriteByte('\n')
+		}
+		inputs = append(inputs, b.String())
+	}
+
+	for _, input := range inputs {
+		for _, batteries := range []int{1, 2, 12, 19} {
+			opts := day3.Options{Batteries: batteries}
+			want, err := day3.ComputeWith(strings.NewReader(input), opts)
+			if errors.Is(err, day3.ErrBankTooShort) {
+				if _, err := day3.ComputeSeekable(strings.NewReader(input), opts); !errors.Is(err, day3.ErrBankTooShort) {
+					t.Fatalf("ComputeSeekable(%q, %d): got %v, want ErrBankTooShort", input, batteries, err)
+				}
+				if _, err := day3.ComputeStream(strings.NewReader(withHeaders(input)), opts); !errors.Is(err, day3.ErrBankTooShort) {
+					t.Fatalf("ComputeStream(%q, %d): got %v, want ErrBankTooShort", input, batteries, err)
+				}
+				continue
+			}
+			if err != nil {
+				t.Fatalf("ComputeWith error: %v", err)
+			}
+
+			got, err := day3.ComputeSeekable(strings.NewReader(input), opts)
+			if err != nil {
+				t.Fatalf("ComputeSeekable(%q) error: %v", input, err)
+			}
+			if got.Banks != want.Banks || got.Total != want.Total || got.BigTotal.String() != want.BigTotal.String() {
+				t.Fatalf("ComputeSeekable(%q, %d)=%+v, want %+v", input, batteries, got, want)
+			}
+
+			got, err = day3.ComputeStream(strings.NewReader(withHeaders(input)), opts)
+			if err != nil {
+				t.Fatalf("ComputeStream(%q) error: %v", input, err)
+			}
+			if got.Banks != want.Banks || got.Total != want.Total || got.BigTotal.String() != want.BigTotal.String() {
+				t.Fatalf("ComputeStream(%q, %d)=%+v, want %+v", input, batteries, got, want)
+			}
+		}
+	}
+}
+
+func TestComputeSeekableLongBank(t *testing.T) {
+	// Longer than the 1 MiB line limit of ComputeWith.
+	bank := strings.Repeat("1", 3<<20) + "9" + strings.Repeat("5", 1<<20)
+	got, err := day3.ComputeSeekable(strings.NewReader(bank+"\n"), day3.Options{Batteries: 3})
+	if err != nil {
+		t.Fatalf("ComputeSeekable error: %v", err)
+	}
+	if got.Banks != 1 || got.Total != 955 {
+		t.Fatalf("got %+v, want 1 bank worth 955", got)
+	}
+}
+
+func TestStreamingErrors(t *testing.T) {
+	cases := []struct {
+		name  string
+		input string
+		want  error
+	}{
+		{"NoBanks", " \n\n", day3.ErrNoBanks},
+		{"TooShort", "1:9\n", day3.ErrBankTooShort},
+		{"LengthMismatch", "3:1234\n", day3.ErrBankLength},
+		{"MissingColon", "12\n", day3.ErrInvalidHeader},
+		{"BadHeader", "x:12\n", day3.ErrInvalidHeader},
+		{"InvalidDigit", "3:1a3\n", day3.ErrInvalidDigit},
+		{"InnerSpace", "3:1 23\n", day3.ErrInvalidDigit},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := day3.ComputeStream(strings.NewReader(tt.input), day3.Options{Batteries: 2}); !errors.Is(err, tt.want) {
+				t.Fatalf("got %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	if _, err := day3.ComputeSeekable(strings.NewReader("12\n1a\n"), day3.Options{Batteries: 2}); !errors.Is(err, day3.ErrInvalidDigit) {
+		t.Fatalf("seekable invalid digit: got %v", err)
+	}
+	if _, err := day3.ComputeSeekable(strings.NewReader("12\n1\n"), day3.Options{Batteries: 2}); !errors.Is(err, day3.ErrBankTooShort) {
+		t.Fatalf("seekable short bank: got %v", err)
+	}
+}
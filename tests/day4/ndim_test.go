@@ -0,0 +1,234 @@
+package day4_test
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	"adventofcode2025/day1/src/day4"
+)
+
+// bruteWavesND simulates the peeling of an N-dimensional box one synchronous
+// wave at a time. Cells are indexed with axis 0 varying fastest.
+func bruteWavesND(size []int, present []bool, k int, wrap bool) []int {
+	present = append([]bool(nil), present...)
+	coords := func(idx int) []int {
+		c := make([]int, len(size))
+		for a, n := range size {
+			c[a] = idx % n
+			idx /= n
+		}
+		return c
+	}
+
+	var waves []int
+	for {
+		var gone []int
+		for idx, ok := range present {
+			if !ok {
+				continue
+			}
+			c := coords(idx)
+			n := 0
+			for other, ok := range present {
+				if !ok {
+					continue
+				}
+				o := coords(other)
+				// Count the offsets in [-1,1]^d mapping c onto o.
+				ways := 1
+				for a := range size {
+					m := 0
+					for d := -1; d <= 1; d++ {
+						v := c[a] + d
+						if wrap {
+							v = ((v % size[a]) + size[a]) % size[a]
+						}
+						if v == o[a] {
+							m++
+						}
+					}
+					ways *= m
+				}
+				if other == idx {
+					ways-- // the zero offset
+				}
+				n += ways
+			}
+			if n < k {
+				gone = append(gone, idx)
+			}
+		}
+		if len(gone) == 0 {
+			return waves
+		}
+		for _, idx := range gone {
+			present[idx] = false
+		}
+		waves = append(waves, len(gone))
+	}
+}
+
+// layered renders an N-dimensional box as layered grids, with one blank line
+// between layers and two between stacks.
+func layered(size []int, present []bool) string {
+	var b strings.Builder
+	w, h := size[0], size[1]
+	for idx := 0; idx < len(present); idx++ {
+		if idx > 0 && idx%w == 0 {
+			b.WriteByte('\n')
+			if idx%(w*h) == 0 {
+				b.WriteByte('\n')
+				if len(size) == 4 && idx%(w*h*size[2]) == 0 {
+					b.WriteByte('\n')
+				}
+			}
+		}
+		if present[idx] {
+			b.WriteByte('@')
+		} else {
+			b.WriteByte('.')
+		}
+	}
+	return b.String()
+}
+
+// tuples lists the occupied cells of an N-dimensional box as coordinates.
+func tuples(size []int, present []bool) string {
+	var b strings.Builder
+	for idx, ok := range present {
+		if !ok {
+			continue
+		}
+		rest := idx
+		parts := make([]string, len(size))
+		for a, n := range size {
+			parts[a] = fmt.Sprint(rest % n)
+			rest /= n
+		}
+		b.WriteString(strings.Join(parts, ",") + "\n")
+	}
+	return b.String()
+}
+
+func TestComputeNDMatchesBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(25))
+	for iter := 0; iter < 150; iter++ {
+		dims := 3 + rng.Intn(2)
+		size := make([]int, dims)
+		cells := 1
+		for a := range size {
+			size[a] = 1 + rng.Intn(4)
+			cells *= size[a]
+		}
+		present := make([]bool, cells)
+		for i := range present {
+			present[i] = rng.Intn(3) > 0
+		}
+		k := 1 + rng.Intn(12)
+		wrap := rng.Intn(3) == 0
+
+		want := bruteWavesND(size, present, k, wrap)
+		res, err := day4.ComputeLayers(strings.NewReader(layered(size, present)), dims, day4.Options{Threshold: k, Wrap: wrap})
+		if err != nil {
+			t.Fatalf("ComputeLayers error: %v", err)
+		}
+		if !reflect.DeepEqual(res.Waves, want) {
+			t.Fatalf("size %v k=%d wrap=%v: ComputeLayers Waves=%v, want %v\n%s", size, k, wrap, res.Waves, want, layered(size, present))
+		}
+
+		if wrap || tuples(size, present) == "" {
+			continue
+		}
+		res, err = day4.ComputeTuples(strings.NewReader(tuples(size, present)), day4.Options{Threshold: k})
+		if err != nil {
+			t.Fatalf("ComputeTuples error: %v", err)
+		}
+		if !reflect.DeepEqual(res.Waves, want) {
+			t.Fatalf("size %v k=%d: ComputeTuples Waves=%v, want %v", size, k, res.Waves, want)
+		}
+	}
+}
+
+func TestComputeND2DMatchesDense(t *testing.T) {
+	input := readExample(t)
+	res, err := day4.ComputeLayers(strings.NewReader(input), 2, day4.Options{})
+	if err != nil {
+		t.Fatalf("ComputeLayers error: %v", err)
+	}
+	if res.TotalRemoved != 43 {
+		t.Fatalf("ComputeLayers TotalRemoved=%d, want 43", res.TotalRemoved)
+	}
+	res, err = day4.ComputeTuples(strings.NewReader(toSparse(input)), day4.Options{FirstWaveOnly: true})
+	if err != nil {
+		t.Fatalf("ComputeTuples error: %v", err)
+	}
+	if res.TotalRemoved != 13 {
+		t.Fatalf("ComputeTuples TotalRemoved=%d, want 13", res.TotalRemoved)
+	}
+}
+
+func TestComputeNDCube(t *testing.T) {
+	// In a full 3x3x3 cube the 8 corners have 7 neighbours, the 12 edge
+	// centres 11, the 6 face centres 17 and the core 26.
+	layer := "@@@\n@@@\n@@@\n"
+	input := layer + "\n" + layer + "\n" + layer
+	res, err := day4.ComputeLayers(strings.NewReader(input), 3, day4.Options{Threshold: 8, FirstWaveOnly: true})
+	if err != nil {
+		t.Fatalf("ComputeLayers error: %v", err)
+	}
+	if res.TotalRemoved != 8 {
+		t.Fatalf("TotalRemoved=%d, want 8", res.TotalRemoved)
+	}
+}
+
+func TestComputeNDErrors(t *testing.T) {
+	cases := []struct {
+		name string
+		run  func() error
+		want error
+	}{
+		{"Dims", func() error {
+			_, err := day4.ComputeLayers(strings.NewReader("@\n"), 5, day4.Options{})
+			return err
+		}, day4.ErrInvalidDimensions},
+		{"FlatLayers", func() error {
+			_, err := day4.ComputeLayers(strings.NewReader("@@\n@@\n\n@@\n@@\n"), 2, day4.Options{})
+			return err
+		}, day4.ErrInvalidDimensions},
+		{"LayerHeights", func() error {
+			_, err := day4.ComputeLayers(strings.NewReader("@@\n@@\n\n@@\n"), 3, day4.Options{})
+			return err
+		}, day4.ErrNonRectangular},
+		{"StackDepths", func() error {
+			_, err := day4.ComputeLayers(strings.NewReader("@\n\n@\n\n\n@\n"), 4, day4.Options{})
+			return err
+		}, day4.ErrNonRectangular},
+		{"Neighborhood", func() error {
+			_, err := day4.ComputeLayers(strings.NewReader("@\n"), 3, day4.Options{Neighborhood: day4.VonNeumann()})
+			return err
+		}, day4.ErrInvalidOptions},
+		{"TupleDims", func() error {
+			_, err := day4.ComputeTuples(strings.NewReader("1,2,3,4,5\n"), day4.Options{})
+			return err
+		}, day4.ErrInvalidDimensions},
+		{"TupleMismatch", func() error {
+			_, err := day4.ComputeTuples(strings.NewReader("1,2,3\n1,2\n"), day4.Options{})
+			return err
+		}, day4.ErrInvalidCoordinate},
+		{"TupleWrap", func() error {
+			_, err := day4.ComputeTuples(strings.NewReader("1,2,3\n"), day4.Options{Wrap: true})
+			return err
+		}, day4.ErrInvalidOptions},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.run(); !errors.Is(err, tt.want) {
+				t.Fatalf("got %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
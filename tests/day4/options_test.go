@@ -0,0 +1,130 @@
+package day4_test
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	"adventofcode2025/day1/src/day4"
+)
+
+// bruteWaves simulates the peeling one synchronous wave at a time.
+func bruteWaves(grid []string, opts day4.Options) []int {
+	h, w := len(grid), len(grid[0])
+	present := make([][]bool, h)
+	for y := range grid {
+		present[y] = make([]bool, w)
+		for x := range grid[y] {
+			present[y][x] = grid[y][x] == '@'
+		}
+	}
+
+	var waves []int
+	for {
+		var gone [][2]int
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				if !present[y][x] {
+					continue
+				}
+				n := 0
+				for _, off := range opts.Neighborhood {
+					ny, nx := y+off.DY, x+off.DX
+					if opts.Wrap {
+						ny, nx = ((ny%h)+h)%h, ((nx%w)+w)%w
+					} else if ny < 0 || ny >= h || nx < 0 || nx >= w {
+						continue
+					}
+					if present[ny][nx] {
+						n++
+					}
+				}
+				if n < opts.Threshold {
+					gone = append(gone, [2]int{y, x})
+				}
+			}
+		}
+		if len(gone) == 0 {
+			return waves
+		}
+		for _, c := range gone {
+			present[c[0]][c[1]] = false
+		}
+		waves = append(waves, len(gone))
+	}
+}
+
+func TestComputeWithNeighborhoodsMatchBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(22))
+	hoods := map[string][]day4.Offset{
+		"moore":      day4.Moore(),
+		"vonneumann": day4.VonNeumann(),
+		"radius2":    day4.Radius(2),
+		"knight":     {{DX: 1, DY: 2}, {DX: 2, DY: 1}, {DX: -1, DY: 2}, {DX: -2, DY: 1}, {DX: 1, DY: -2}, {DX: 2, DY: -1}, {DX: -1, DY: -2}, {DX: -2, DY: -1}},
+		"asymmetric": {{DX: 1, DY: 0}, {DX: 0, DY: 1}, {DX: 1, DY: 1}},
+	}
+
+	for iter := 0; iter < 300; iter++ {
+		h, w := 1+rng.Intn(8), 1+rng.Intn(8)
+		grid := make([]string, h)
+		for y := range grid {
+			row := make([]byte, w)
+			for x := range row {
+				row[x] = '.'
+				if rng.Intn(3) > 0 {
+					row[x] = '@'
+				}
+			}
+			grid[y] = string(row)
+		}
+		for name, hood := range hoods {
+			opts := day4.Options{Threshold: 1 + rng.Intn(len(hood)), Neighborhood: hood, Wrap: rng.Intn(2) == 0}
+			res, err := day4.ComputeWith(strings.NewReader(strings.Join(grid, "\n")), opts)
+			if err != nil {
+				t.Fatalf("ComputeWith error: %v", err)
+			}
+			want := bruteWaves(grid, opts)
+			if !reflect.DeepEqual(res.Waves, want) {
+				t.Fatalf("%s k=%d wrap=%v grid=%q: Waves=%v, want %v", name, opts.Threshold, opts.Wrap, grid, res.Waves, want)
+			}
+		}
+	}
+}
+
+func TestComputeWithDefaultsMatchCompute(t *testing.T) {
+	input := readExample(t)
+	res, err := day4.ComputeWith(strings.NewReader(input), day4.Options{Threshold: day4.DefaultThreshold, Neighborhood: day4.Moore()})
+	if err != nil {
+		t.Fatalf("ComputeWith error: %v", err)
+	}
+	if res.TotalRemoved != 43 {
+		t.Fatalf("TotalRemoved=%d, want 43", res.TotalRemoved)
+	}
+}
+
+func TestComputeWithWrap(t *testing.T) {
+	// On a torus the border rolls of a full grid keep all 8 neighbours.
+	input := strings.Repeat("@@@@\n", 4)
+	res, err := day4.ComputeWith(strings.NewReader(input), day4.Options{Wrap: true})
+	if err != nil {
+		t.Fatalf("ComputeWith error: %v", err)
+	}
+	if res.TotalRemoved != 0 {
+		t.Fatalf("TotalRemoved=%d, want 0", res.TotalRemoved)
+	}
+}
+
+func TestComputeWithInvalidOptions(t *testing.T) {
+	cases := []day4.Options{
+		{Threshold: -1},
+		{Neighborhood: []day4.Offset{{DX: 0, DY: 0}}},
+		{Neighborhood: []day4.Offset{{DX: 1, DY: 0}, {DX: 1, DY: 0}}},
+	}
+	for _, opts := range cases {
+		if _, err := day4.ComputeWith(strings.NewReader("@@\n"), opts); !errors.Is(err, day4.ErrInvalidOptions) {
+			t.Fatalf("%+v: got %v, want ErrInvalidOptions", opts, err)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package day4_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"adventofcode2025/day1/src/aoc"
+	"adventofcode2025/day1/src/day4"
+)
+
+func TestComputeWithSurvivors(t *testing.T) {
+	res, err := day4.ComputeWith(strings.NewReader(readExample(t)), day4.Options{Survivors: true, RemovalOrder: true})
+	if err != nil {
+		t.Fatalf("ComputeWith error: %v", err)
+	}
+	want := []string{
+		"..........",
+		"..........",
+		"..........",
+		"....@@....",
+		"...@@@@...",
+		"...@@@@@..",
+		"...@.@.@@.",
+		"...@@.@@@.",
+		"...@@@@@..",
+		"....@@@...",
+	}
+	if strings.Join(res.Survivors, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("Survivors:\n%s\nwant:\n%s", strings.Join(res.Survivors, "\n"), strings.Join(want, "\n"))
+	}
+
+	// The removal map must agree with the wave history and the survivors.
+	perWave := make([]int, len(res.Waves))
+	for y, row := range res.RemovedIn {
+		for x, wave := range row {
+			switch {
+			case wave == day4.Survivor:
+				if res.Survivors[y][x] != '@' {
+					t.Fatalf("cell %d,%d marked as survivor", x, y)
+				}
+			case wave == day4.Empty:
+				if res.Survivors[y][x] != '.' {
+					t.Fatalf("cell %d,%d marked as empty", x, y)
+				}
+			default:
+				perWave[wave-1]++
+			}
+		}
+	}
+	for i := range perWave {
+		if perWave[i] != res.Waves[i] {
+			t.Fatalf("wave %d: %d cells in RemovedIn, Waves says %d", i+1, perWave[i], res.Waves[i])
+		}
+	}
+}
+
+func TestComputeOutputsAreOptional(t *testing.T) {
+	res, err := day4.Compute(strings.NewReader(readExample(t)))
+	if err != nil {
+		t.Fatalf("Compute error: %v", err)
+	}
+	if res.Survivors != nil || res.RemovedIn != nil {
+		t.Fatalf("outputs set without being requested: %+v", res)
+	}
+}
+
+func TestReportFormats(t *testing.T) {
+	input := "@@@\n@@@\n@@@\n..@\n"
+
+	var out bytes.Buffer
+	if err := aoc.Report(4, 2, "waves", strings.NewReader(input), &out); err != nil {
+		t.Fatalf("waves report error: %v", err)
+	}
+	want := "1 2 1\n2 3 3\n1 3 2\n. . 1\n"
+	if out.String() != want {
+		t.Fatalf("waves report:\n%s\nwant:\n%s", out.String(), want)
+	}
+
+	out.Reset()
+	if err := aoc.Report(4, 1, "grid", strings.NewReader(input), &out); err != nil {
+		t.Fatalf("grid report error: %v", err)
+	}
+	want = ".@.\n@@@\n.@@\n...\n"
+	if out.String() != want {
+		t.Fatalf("grid report:\n%s\nwant:\n%s", out.String(), want)
+	}
+
+	// Without a format the grid is written.
+	out.Reset()
+	if err := aoc.Report(4, 1, "", strings.NewReader(input), &out); err != nil || out.String() != want {
+		t.Fatalf("default report: %v\n%s\nwant:\n%s", err, out.String(), want)
+	}
+
+	if err := aoc.Report(4, 2, "json", strings.NewReader(input), &out); !errors.Is(err, aoc.ErrUnknownFormat) {
+		t.Fatalf("unknown format: got %v", err)
+	}
+}
@@ -0,0 +1,126 @@
+package day4_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	"adventofcode2025/day1/src/day4"
+)
+
+// toSparse lists the rolls of a dense grid as "x,y" lines.
+func toSparse(dense string) string {
+	var b strings.Builder
+	y := 0
+	for _, line := range strings.Split(dense, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		for x, ch := range line {
+			if ch == '@' {
+				fmt.Fprintf(&b, "%d,%d\n", x, y)
+			}
+		}
+		y++
+	}
+	return b.String()
+}
+
+func TestComputeSparseMatchesDense(t *testing.T) {
+	inputs := []string{readExample(t), "@@@\n@@@\n@@@\n..@\n"}
+	rng := rand.New(rand.NewSource(24))
+	for i := 0; i < 100; i++ {
+		h, w := 1+rng.Intn(12), 1+rng.Intn(12)
+		var b strings.Builder
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				if rng.Intn(3) > 0 {
+					b.WriteByte('@')
+				} else {
+					b.WriteByte('.')
+				}
+			}
+			b.WriteByte('\n')
+		}
+		inputs = append(inputs, b.String())
+	}
+
+	optsList := []day4.Options{
+		{},
+		{FirstWaveOnly: true},
+		{Threshold: 2, Neighborhood: day4.VonNeumann()},
+		{Threshold: 9, Neighborhood: day4.Radius(2)},
+		{Threshold: 2, Neighborhood: []day4.Offset{{DX: 1, DY: 0}, {DX: 0, DY: 1}, {DX: 1, DY: 1}}},
+	}
+	for _, input := range inputs {
+		sparse := toSparse(input)
+		if sparse == "" {
+			continue
+		}
+		for _, opts := range optsList {
+			dense, err := day4.ComputeWith(strings.NewReader(input), opts)
+			if err != nil {
+				t.Fatalf("ComputeWith error: %v", err)
+			}
+			got, err := day4.ComputeSparse(strings.NewReader(sparse), opts)
+			if err != nil {
+				t.Fatalf("ComputeSparse error: %v", err)
+			}
+			if got.TotalRemoved != dense.TotalRemoved || !reflect.DeepEqual(got.Waves, dense.Waves) {
+				t.Fatalf("opts %+v input %q: sparse %+v, dense %+v", opts, input, got, dense)
+			}
+		}
+	}
+}
+
+func TestComputeSparseHugeCoordinates(t *testing.T) {
+	// Two far-apart 2x2 blocks: every roll has 3 neighbours and goes at once.
+	input := "0,0\n1,0\n0,1\n1,1\n-100000,99999\n-99999,99999\n-100000,100000\n-99999,100000\n"
+	res, err := day4.ComputeSparse(strings.NewReader(input), day4.Options{})
+	if err != nil {
+		t.Fatalf("ComputeSparse error: %v", err)
+	}
+	if res.TotalRemoved != 8 || !reflect.DeepEqual(res.Waves, []int{8}) {
+		t.Fatalf("got %+v, want 8 rolls in one wave", res)
+	}
+
+	// Cells at both ends of the int range are not neighbours of each other.
+	input = fmt.Sprintf("%d,0\n%d,0\n", math.MaxInt, math.MinInt)
+	res, err = day4.ComputeSparse(strings.NewReader(input), day4.Options{Threshold: 1})
+	if err != nil {
+		t.Fatalf("ComputeSparse error: %v", err)
+	}
+	if !reflect.DeepEqual(res.Waves, []int{2}) {
+		t.Fatalf("got %+v, want both rolls in wave 1", res)
+	}
+}
+
+func TestComputeSparseErrors(t *testing.T) {
+	cases := []struct {
+		name  string
+		input string
+		opts  day4.Options
+		want  error
+	}{
+		{"Empty", "\n  \n", day4.Options{}, day4.ErrNoGrid},
+		{"MissingComma", "1 2\n", day4.Options{}, day4.ErrInvalidCoordinate},
+		{"NotANumber", "1,x\n", day4.Options{}, day4.ErrInvalidCoordinate},
+		{"Duplicate", "1,2\n 1, 2 \n", day4.Options{}, day4.ErrDuplicateCell},
+		{"Wrap", "1,2\n", day4.Options{Wrap: true}, day4.ErrInvalidOptions},
+		{"Survivors", "1,2\n", day4.Options{Survivors: true}, day4.ErrInvalidOptions},
+		{"IntLimits", fmt.Sprintf("%d,0\n%d,0\n", math.MaxInt, math.MinInt), day4.Options{Threshold: 1}, nil},
+		{"OffsetLimits", "0,0\n", day4.Options{Neighborhood: []day4.Offset{{DX: math.MinInt, DY: 0}}}, nil},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := day4.ComputeSparse(strings.NewReader(tt.input), tt.opts); !errors.Is(err, tt.want) {
+				t.Fatalf("got %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
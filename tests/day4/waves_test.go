@@ -0,0 +1,74 @@
+package day4_test
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"adventofcode2025/day1/src/day4"
+)
+
+func readExample(t *testing.T) string {
+	t.Helper()
+	data, err := os.ReadFile("../../input4test.txt")
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	return string(data)
+}
+
+func TestComputeWaveHistory(t *testing.T) {
+	res, err := day4.Compute(strings.NewReader(readExample(t)))
+	if err != nil {
+		t.Fatalf("Compute error: %v", err)
+	}
+	want := []int{13, 12, 7, 5, 2, 1, 1, 1, 1}
+	if !reflect.DeepEqual(res.Waves, want) {
+		t.Fatalf("Waves=%v, want %v", res.Waves, want)
+	}
+	if res.TotalRemoved != 43 {
+		t.Fatalf("TotalRemoved=%d, want 43", res.TotalRemoved)
+	}
+}
+
+func TestComputeFirstWaveOnly(t *testing.T) {
+	res, err := day4.ComputeWith(strings.NewReader(readExample(t)), day4.Options{FirstWaveOnly: true})
+	if err != nil {
+		t.Fatalf("ComputeWith error: %v", err)
+	}
+	if res.TotalRemoved != 13 || !reflect.DeepEqual(res.Waves, []int{13}) {
+		t.Fatalf("got TotalRemoved=%d Waves=%v, want 13 [13]", res.TotalRemoved, res.Waves)
+	}
+}
+
+func TestComputeWavesOfBlock(t *testing.T) {
+	// In a 3x3 block only the corners start with fewer than 4 neighbours. The
+	// edges drop to 3 once they are gone, and the centre goes last.
+	res, err := day4.Compute(strings.NewReader("@@@\n@@@\n@@@\n"))
+	if err != nil {
+		t.Fatalf("Compute error: %v", err)
+	}
+	if !reflect.DeepEqual(res.Waves, []int{4, 4, 1}) {
+		t.Fatalf("Waves=%v, want [4 4 1]", res.Waves)
+	}
+
+	res, err = day4.Compute(strings.NewReader("...\n...\n"))
+	if err != nil {
+		t.Fatalf("Compute error: %v", err)
+	}
+	if res.TotalRemoved != 0 || len(res.Waves) != 0 {
+		t.Fatalf("empty grid: got %+v", res)
+	}
+}
+
+func TestComputeStableGrid(t *testing.T) {
+	// On a torus every roll of a full 3x3 grid has 8 neighbours, so none goes.
+	res, err := day4.ComputeWith(strings.NewReader("@@@\n@@@\n@@@\n"), day4.Options{Wrap: true})
+	if err != nil {
+		t.Fatalf("ComputeWith error: %v", err)
+	}
+	if res.TotalRemoved != 0 || len(res.Waves) != 0 {
+		t.Fatalf("got TotalRemoved=%d Waves=%v, want nothing removed", res.TotalRemoved, res.Waves)
+	}
+}